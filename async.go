@@ -0,0 +1,215 @@
+package go_forms
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// AsyncValidator is a validator that may block, e.g. because it has to do I/O.
+// It returns nil if the field is valid and the error to display otherwise.
+// Implementations should return early when the context is cancelled, which happens when the value changes while the validation is running.
+type AsyncValidator interface {
+	ValidateContext(ctx context.Context, field any) error
+}
+
+type CustomAsyncValidator struct {
+	Validator func(ctx context.Context, field any) error
+}
+
+func (v *CustomAsyncValidator) ValidateContext(ctx context.Context, field any) error {
+	return v.Validator(ctx, field)
+}
+
+// ErrValidationPending is the error of fields whose asynchronous validation has not finished yet
+var ErrValidationPending = &CustomError{Message: "Validation pending"}
+
+// asyncValidation holds the state of the asynchronous validation of a field
+type asyncValidation struct {
+	mu        sync.Mutex
+	cancel    context.CancelFunc
+	timer     *time.Timer
+	done      chan struct{}
+	pending   bool
+	validated bool
+	value     string
+	err       error
+}
+
+// IsPending returns true while the asynchronous validators of the field are running or waiting for the debounce delay
+func (f *FieldBaseType) IsPending() bool {
+	f.async.mu.Lock()
+	defer f.async.mu.Unlock()
+	return f.async.pending
+}
+
+// startAsyncValidation cancels the running asynchronous validation and starts a new one for the current value after the given delay
+func (f *FieldBaseType) startAsyncValidation(delay time.Duration) {
+	if len(f.AsyncValidators) == 0 {
+		return
+	}
+	f.async.mu.Lock()
+	defer f.async.mu.Unlock()
+	f.stopAsyncValidation()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	f.async.cancel = cancel
+	f.async.done = done
+	f.async.pending = true
+	f.async.validated = false
+	f.async.value = f.GetValue()
+	f.async.timer = time.AfterFunc(delay, func() {
+		f.runAsyncValidation(ctx, done)
+	})
+}
+
+// stopAsyncValidation cancels the running asynchronous validation, the caller has to hold the lock
+func (f *FieldBaseType) stopAsyncValidation() {
+	if f.async.timer != nil {
+		f.async.timer.Stop()
+	}
+	if f.async.cancel != nil {
+		f.async.cancel()
+	}
+	if f.async.done != nil && f.async.pending {
+		close(f.async.done)
+	}
+	f.async.timer = nil
+	f.async.cancel = nil
+	f.async.done = nil
+	f.async.pending = false
+}
+
+func (f *FieldBaseType) runAsyncValidation(ctx context.Context, done chan struct{}) {
+	var err error
+	for _, validator := range f.AsyncValidators {
+		if err = validator.ValidateContext(ctx, f); err != nil {
+			break
+		}
+	}
+	f.async.mu.Lock()
+	if f.async.done != done {
+		// The value changed while the validators were running
+		f.async.mu.Unlock()
+		return
+	}
+	f.async.cancel()
+	f.async.pending = false
+	f.async.validated = true
+	f.async.err = err
+	f.async.timer = nil
+	f.async.cancel = nil
+	f.async.done = nil
+	f.async.mu.Unlock()
+	close(done)
+	if f.form != nil {
+		f.form.notifyValidation(f.Id)
+	}
+}
+
+// isAsyncValid checks the result of the asynchronous validators and starts them if the current value was not validated yet
+func (f *FieldBaseType) isAsyncValid() bool {
+	if len(f.AsyncValidators) == 0 {
+		return true
+	}
+	f.async.mu.Lock()
+	validated := f.async.validated && f.async.value == f.GetValue()
+	pending := f.async.pending && f.async.value == f.GetValue()
+	err := f.async.err
+	f.async.mu.Unlock()
+	if !validated && !pending {
+		f.startAsyncValidation(0)
+		pending = true
+	}
+	if pending {
+		f.setError(ErrValidationPending)
+		return false
+	}
+	if err != nil {
+		f.setError(err)
+		return false
+	}
+	return true
+}
+
+// waitAsyncValidation waits until the asynchronous validation of the current value has finished
+func (f *FieldBaseType) waitAsyncValidation(ctx context.Context) error {
+	for {
+		f.async.mu.Lock()
+		if f.async.validated && f.async.value == f.GetValue() {
+			f.async.mu.Unlock()
+			return nil
+		}
+		if !f.async.pending || f.async.value != f.GetValue() {
+			f.async.mu.Unlock()
+			f.startAsyncValidation(0)
+			continue
+		}
+		done := f.async.done
+		f.async.mu.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (f *Form) notifyValidation(fieldId string) {
+	f.stateMu.RLock()
+	listeners := slices.Clone(f.validationListeners)
+	dispatch := f.dispatch
+	f.stateMu.RUnlock()
+	dispatch(func() {
+		for _, listener := range listeners {
+			(*listener)(fieldId)
+		}
+	})
+}
+
+// addValidationListener adds a listener that is called after asynchronous validations and when fields are touched and returns a function
+// removing it. The field id is empty if the state of all fields changed.
+func (f *Form) addValidationListener(listener func(fieldId string)) (remove func()) {
+	f.stateMu.Lock()
+	defer f.stateMu.Unlock()
+	added := &listener
+	f.validationListeners = append(f.validationListeners, added)
+	return func() {
+		f.stateMu.Lock()
+		defer f.stateMu.Unlock()
+		f.validationListeners = slices.DeleteFunc(f.validationListeners, func(listener *func(fieldId string)) bool { return listener == added })
+	}
+}
+
+func (f *Form) hasAsyncValidators() bool {
+	for _, field := range f.GetAllFields() {
+		if base := getFieldBase(field); base != nil && len(base.AsyncValidators) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateContext waits for the asynchronous validators of all displayed fields and validates the form.
+// It returns the error of the first invalid field, nil if the form is valid or the context error if the context ends first.
+func (f *Form) ValidateContext(ctx context.Context) error {
+	if err := f.waitAsyncValidation(ctx); err != nil {
+		return err
+	}
+	return f.GetError()
+}
+
+// waitAsyncValidation waits for the asynchronous validators of all displayed fields
+func (f *Form) waitAsyncValidation(ctx context.Context) error {
+	for _, field := range f.GetAllFields() {
+		base := getFieldBase(field)
+		if base == nil || len(base.AsyncValidators) == 0 || !field.ShouldDisplay() {
+			continue
+		}
+		if err := base.waitAsyncValidation(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+// Command go-forms presents a form described by a JSON schema and writes the submitted values to stdout.
+//
+// Usage:
+//
+//	go-forms [flags] schema.json
+//
+// The form is asked in the terminal (prompts are written to stderr) or, with --gui, shown in a window.
+// Values can be supplied with --set, --env and --answers, with --no-input the form is filled from them without asking.
+// The exit code is 0 if the form was submitted, 1 if it was cancelled and 2 on errors.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/app"
+	forms "github.com/CUBUS-mc/go-forms"
+)
+
+const (
+	exitSubmitted = 0
+	exitCancelled = 1
+	exitError     = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command with the arguments (without the program name) and returns the exit code
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("go-forms", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	gui := flags.Bool("gui", false, "show the form in a window instead of the terminal")
+	format := flags.String("format", "json", "output format of the values (json, env or yaml)")
+	prefix := flags.String("prefix", "", "prefix of the variable names in the env format")
+	title := flags.String("title", "", "title of the window (defaults to the title of the schema)")
+	answers := flags.String("answers", "", "JSON file with values for the fields")
+	env := flags.Bool("env", false, "read values from environment variables named like the env output (using --prefix)")
+	noInput := flags.Bool("no-input", false, "fill the form from the supplied values without asking")
+	set := make(forms.MapSource)
+	flags.Func("set", "value for a field as id=value (can be repeated)", func(arg string) error {
+		values, err := forms.ParseArgs([]string{arg})
+		for id, value := range values {
+			set[id] = value
+		}
+		return err
+	})
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: go-forms [flags] schema.json")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitSubmitted
+		}
+		return exitError
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitError
+	}
+	formatter, ok := formatters[*format]
+	if !ok {
+		fmt.Fprintln(stderr, "Unknown output format: "+*format)
+		return exitError
+	}
+
+	data, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	schema, err := forms.ParseSchema(data)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	form, err := schema.NewForm()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	if *title == "" {
+		*title = schema.Title
+	}
+
+	sources := []forms.ValueSource{set}
+	if *env {
+		sources = append(sources, &forms.EnvSource{Prefix: *prefix})
+	}
+	if *answers != "" {
+		answerFile, err := forms.LoadAnswerFile(*answers)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitError
+		}
+		sources = append(sources, answerFile)
+	}
+
+	switch {
+	case *noInput:
+		_, err = form.Fill(context.Background(), sources...)
+		var fillError *forms.FillError
+		if errors.As(err, &fillError) {
+			for _, fieldError := range fillError.Errors {
+				fmt.Fprintln(stderr, fieldError)
+			}
+			return exitError
+		}
+	case *gui:
+		form.Prefill(sources...)
+		// The prefilled values are the starting point, cancelling without further changes needs no confirmation
+		form.MarkPristine()
+		err = showWindow(*title, form)
+	default:
+		form.Prefill(sources...)
+		if *title != "" {
+			fmt.Fprintln(stderr, *title)
+		}
+		_, err = forms.FormToTerminal(form, stdin, stderr)
+	}
+	if errors.Is(err, forms.ErrCancelled) {
+		return exitCancelled
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	output, err := formatter(submittedValues(form), *prefix)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	fmt.Fprint(stdout, output)
+	return exitSubmitted
+}
+
+// showWindow shows the form in a window and returns ErrCancelled if the window was closed without submitting the form
+func showWindow(title string, form *forms.Form) error {
+	if title == "" {
+		title = "go-forms"
+	}
+	formsApp := app.New()
+	window := formsApp.NewWindow(title)
+	submitted := false
+	formWidget := forms.NewFyneForm(form, window)
+	formWidget.OnSubmit = func(_ map[string]string) {
+		submitted = true
+		window.Close()
+	}
+	formWidget.OnCancel = window.Close
+	window.SetContent(formWidget)
+	window.Resize(fyne.NewSize(700, 400))
+	window.ShowAndRun()
+	if !submitted {
+		return forms.ErrCancelled
+	}
+	return nil
+}
+
+// submittedValues returns the values of all input fields including the ones nested in groups (messages are left out)
+func submittedValues(form *forms.Form) map[string]string {
+	values := form.GetAllFieldValues()
+	for _, field := range form.GetAllFields() {
+		if _, ok := field.(*forms.Message); ok {
+			delete(values, field.GetId())
+		}
+	}
+	return values
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+	"title": "Server",
+	"fields": [
+		{"type": "text", "id": "name", "prompt": "Name", "required": true},
+		{"type": "number", "id": "port", "prompt": "Port", "default": "25565", "validators": [{"type": "port"}]}
+	]
+}`
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		schema string
+		stdin  string
+		code   int
+		stdout string
+		// stderr is a part of the output on stderr
+		stderr string
+	}{
+		{
+			name:   "submitted in the terminal",
+			stdin:  "lobby\n\n",
+			code:   exitSubmitted,
+			stdout: "{\n  \"name\": \"lobby\",\n  \"port\": \"25565\"\n}\n",
+			stderr: "Server\nName *: ",
+		},
+		{
+			name:   "env output with prefilled values",
+			args:   []string{"--format", "env", "--prefix", "MC_", "--set", "port=25566"},
+			stdin:  "lobby\n\n",
+			code:   exitSubmitted,
+			stdout: "MC_NAME='lobby'\nMC_PORT='25566'\n",
+		},
+		{
+			name:   "filled without input",
+			args:   []string{"--no-input", "--format", "yaml", "--set", "name=lobby"},
+			code:   exitSubmitted,
+			stdout: "name: \"lobby\"\nport: \"25565\"\n",
+		},
+		{
+			name:   "cancelled",
+			stdin:  "lobby\n",
+			code:   exitCancelled,
+			stderr: "Name *: ",
+		},
+		{
+			name:   "invalid answers",
+			args:   []string{"--no-input", "--set", "port=70000"},
+			code:   exitError,
+			stderr: "port",
+		},
+		{
+			name:   "invalid schema",
+			schema: `{"fields": [{"type": "date", "id": "start"}]}`,
+			code:   exitError,
+			stderr: "Invalid schema at fields[0].type: unknown field type (type: date)",
+		},
+		{
+			name:   "schema that is no JSON",
+			schema: `{"fields": [`,
+			code:   exitError,
+		},
+		{
+			name:   "unknown format",
+			args:   []string{"--format", "toml"},
+			code:   exitError,
+			stderr: "Unknown output format: toml",
+		},
+		{
+			name:   "help",
+			args:   []string{"--help"},
+			code:   exitSubmitted,
+			stderr: "Usage: go-forms [flags] schema.json",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schema := test.schema
+			if schema == "" {
+				schema = testSchema
+			}
+			path := filepath.Join(t.TempDir(), "schema.json")
+			if err := os.WriteFile(path, []byte(schema), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			var stdout, stderr bytes.Buffer
+			code := run(append(test.args, path), strings.NewReader(test.stdin), &stdout, &stderr)
+			if code != test.code {
+				t.Errorf("exit code is %d, expected %d (stderr: %s)", code, test.code, stderr.String())
+			}
+			if stdout.String() != test.stdout {
+				t.Errorf("stdout is %q, expected %q", stdout.String(), test.stdout)
+			}
+			if !strings.Contains(stderr.String(), test.stderr) {
+				t.Errorf("stderr does not contain %q: %s", test.stderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunWithoutSchema(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run(nil, strings.NewReader(""), &stdout, &stderr); code != exitError {
+		t.Errorf("exit code is %d, expected %d", code, exitError)
+	}
+	if !strings.Contains(stderr.String(), "Usage: go-forms [flags] schema.json") {
+		t.Errorf("stderr does not show the usage: %s", stderr.String())
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	forms "github.com/CUBUS-mc/go-forms"
+)
+
+// formatters convert the submitted values to the output formats
+var formatters = map[string]func(values map[string]string, prefix string) (string, error){
+	"json": formatJson,
+	"env":  formatEnv,
+	"yaml": formatYaml,
+}
+
+var plainYamlKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func formatJson(values map[string]string, _ string) (string, error) {
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// formatEnv writes one KEY='value' line per field that can be used with eval or source in shell scripts
+func formatEnv(values map[string]string, prefix string) (string, error) {
+	var builder strings.Builder
+	for _, key := range sortedKeys(values) {
+		builder.WriteString(forms.EnvVariableName(prefix, key) + "='" + strings.ReplaceAll(values[key], "'", `'\''`) + "'\n")
+	}
+	return builder.String(), nil
+}
+
+// formatYaml writes a flat YAML mapping, the values are written as double quoted strings
+func formatYaml(values map[string]string, _ string) (string, error) {
+	var builder strings.Builder
+	for _, key := range sortedKeys(values) {
+		if !plainYamlKey.MatchString(key) {
+			key = strconv.Quote(key)
+		}
+		builder.WriteString(key + ": " + strconv.Quote(values[key]) + "\n")
+	}
+	return builder.String(), nil
+}
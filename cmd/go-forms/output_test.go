@@ -0,0 +1,69 @@
+package main
+
+import "testing"
+
+func TestFormatters(t *testing.T) {
+	values := map[string]string{
+		"name":      "lobby",
+		"motd":      "It's \"fun\"\nhere",
+		"max-ram":   "2048",
+		"1st world": "",
+	}
+	tests := []struct {
+		format string
+		prefix string
+		output string
+	}{
+		{
+			format: "json",
+			output: `{
+  "1st world": "",
+  "max-ram": "2048",
+  "motd": "It's \"fun\"\nhere",
+  "name": "lobby"
+}
+`,
+		},
+		{
+			format: "env",
+			prefix: "SERVER_",
+			// Values are single quoted, a single quote ends the quotes, is escaped and starts them again
+			output: `SERVER_1ST_WORLD=''
+SERVER_MAX_RAM='2048'
+SERVER_MOTD='It'\''s "fun"
+here'
+SERVER_NAME='lobby'
+`,
+		},
+		{
+			format: "env",
+			// Names cannot start with a digit
+			output: `_1ST_WORLD=''
+MAX_RAM='2048'
+MOTD='It'\''s "fun"
+here'
+NAME='lobby'
+`,
+		},
+		{
+			format: "yaml",
+			// Keys that are not plain YAML keys and all values are double quoted
+			output: `"1st world": ""
+max-ram: "2048"
+motd: "It's \"fun\"\nhere"
+name: "lobby"
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.format+" "+test.prefix, func(t *testing.T) {
+			output, err := formatters[test.format](values, test.prefix)
+			if err != nil {
+				t.Fatalf("%s returned %v", test.format, err)
+			}
+			if output != test.output {
+				t.Errorf("output is\n%s\nexpected\n%s", output, test.output)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package go_forms
+
+import "strings"
+
+// Defining the Validator combinators
+
+// NotValidator is valid if the Validator fails, Message is the error otherwise (defaults to "Field value is not allowed")
+type NotValidator struct {
+	Validator Validator
+	Message   string
+}
+
+func (v *NotValidator) GetDependencies() []string {
+	return validatorDependencies(v.Validator)
+}
+
+func (v *NotValidator) Validate(field any) bool {
+	base := getFieldBase(field)
+	previousError := base.GetError()
+	if !v.Validator.Validate(field) {
+		// The error of the inner validator is not the error of the field
+		base.setError(previousError)
+		return true
+	}
+	message := v.Message
+	if message == "" {
+		message = "Field value is not allowed"
+	}
+	base.setError(&CustomError{Code: ErrorCodeNot, Message: message})
+	return false
+}
+
+// AnyOfValidator is valid if at least one of the Validators is valid, the error lists the errors of all of them
+type AnyOfValidator struct {
+	Validators []Validator
+}
+
+func (v *AnyOfValidator) GetDependencies() []string {
+	return validatorDependencies(v.Validators...)
+}
+
+func (v *AnyOfValidator) Validate(field any) bool {
+	base := getFieldBase(field)
+	var messages []string
+	for _, validator := range v.Validators {
+		base.setError(nil)
+		if validator.Validate(field) {
+			return true
+		}
+		messages = appendErrorMessage(messages, base.GetError())
+	}
+	if len(v.Validators) == 0 {
+		return true
+	}
+	message := "Field value is not valid"
+	if len(messages) > 0 {
+		message = "Field has to satisfy one of the following: " + strings.Join(messages, " or ")
+	}
+	base.setError(&CustomError{Code: ErrorCodeAnyOf, Message: message})
+	return false
+}
+
+// AllOfValidator is valid if all Validators are valid. If only one fails its error is kept, otherwise the errors are merged.
+type AllOfValidator struct {
+	Validators []Validator
+}
+
+func (v *AllOfValidator) GetDependencies() []string {
+	return validatorDependencies(v.Validators...)
+}
+
+func (v *AllOfValidator) Validate(field any) bool {
+	base := getFieldBase(field)
+	var errors []error
+	for _, validator := range v.Validators {
+		base.setError(nil)
+		if !validator.Validate(field) {
+			errors = append(errors, base.GetError())
+		}
+	}
+	switch len(errors) {
+	case 0:
+		return true
+	case 1:
+		if errors[0] == nil {
+			errors[0] = &CustomError{Code: ErrorCodeAllOf, Message: "Field value is not valid"}
+		}
+		base.setError(errors[0])
+	default:
+		var messages []string
+		for _, err := range errors {
+			messages = appendErrorMessage(messages, err)
+		}
+		base.setError(&CustomError{Code: ErrorCodeAllOf, Message: "Field has to satisfy all of the following: " + strings.Join(messages, "; ")})
+	}
+	return false
+}
+
+// WhenValidator only applies the Validator if the Condition is met, without a Condition the Validator always applies
+type WhenValidator struct {
+	Condition DisplayCondition
+	Validator Validator
+}
+
+func (v *WhenValidator) GetDependencies() []string {
+	return append(conditionDependencies(v.Condition), validatorDependencies(v.Validator)...)
+}
+
+func (v *WhenValidator) Validate(field any) bool {
+	if v.Condition != nil && !v.Condition.DisplayCondition(getFieldBase(field)) {
+		return true
+	}
+	return v.Validator.Validate(field)
+}
+
+func appendErrorMessage(messages []string, err error) []string {
+	if err == nil {
+		return messages
+	}
+	return append(messages, err.Error())
+}
+
+// validatorDependencies returns the dependencies of the validators that look at other fields
+func validatorDependencies(validators ...Validator) []string {
+	var ids []string
+	for _, validator := range validators {
+		if dependent, ok := validator.(DependentValidator); ok {
+			ids = append(ids, dependent.GetDependencies()...)
+		}
+	}
+	return ids
+}
+
+// conditionDependencies returns the ids of the fields the display condition looks at, as far as they are known
+func conditionDependencies(condition DisplayCondition) []string {
+	switch condition := condition.(type) {
+	case *HasValueDisplayCondition:
+		return []string{condition.FieldId}
+	case *DisplayAfter:
+		return []string{condition.FieldId}
+	case *IsValidDisplayCondition:
+		return condition.FieldIds
+	case *IsInvalidDisplayCondition:
+		return condition.FieldIds
+	case *ExpressionDisplayCondition:
+		return condition.Expression.References()
+	case *NotDisplayCondition:
+		return conditionDependencies(condition.Condition)
+	case *AndDisplayCondition:
+		return conditionsDependencies(condition.Conditions)
+	case *OrDisplayCondition:
+		return conditionsDependencies(condition.Conditions)
+	default:
+		return nil
+	}
+}
+
+func conditionsDependencies(conditions []DisplayCondition) []string {
+	var ids []string
+	for _, condition := range conditions {
+		ids = append(ids, conditionDependencies(condition)...)
+	}
+	return ids
+}
+
+// Defining the Display Condition combinators (see OrDisplayCondition and AndDisplayCondition)
+
+// NotDisplayCondition displays the field if the Condition is not met
+type NotDisplayCondition struct {
+	Condition DisplayCondition
+}
+
+func (d *NotDisplayCondition) DisplayCondition(field any) bool {
+	return !d.Condition.DisplayCondition(field)
+}
+
+// Collecting the expressions nested in the combinators (see CheckExpressions)
+
+func (d *NotDisplayCondition) expressions() []*Expression {
+	return conditionExpressions(d.Condition)
+}
+
+func (v *NotValidator) expressions() []*Expression {
+	return validatorExpressions(v.Validator)
+}
+
+func (v *AnyOfValidator) expressions() []*Expression {
+	return validatorExpressions(v.Validators...)
+}
+
+func (v *AllOfValidator) expressions() []*Expression {
+	return validatorExpressions(v.Validators...)
+}
+
+func (v *WhenValidator) expressions() []*Expression {
+	return append(conditionExpressions(v.Condition), validatorExpressions(v.Validator)...)
+}
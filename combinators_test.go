@@ -0,0 +1,173 @@
+package go_forms
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestValidatorCombinators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator Validator
+		value     string
+		// message is the error of the field, valid values have none
+		message string
+		code    string
+	}{
+		{
+			name:      "not with a failing validator",
+			validator: &NotValidator{Validator: &OneOfValidator{Values: []string{"admin", "root"}}},
+			value:     "steve",
+		},
+		{
+			name:      "not with a valid validator",
+			validator: &NotValidator{Validator: &OneOfValidator{Values: []string{"admin", "root"}}},
+			value:     "admin",
+			message:   "Field value is not allowed",
+			code:      ErrorCodeNot,
+		},
+		{
+			name:      "not with a message",
+			validator: &NotValidator{Validator: &PrefixValidator{Prefix: "_"}, Message: "Field cannot start with _"},
+			value:     "_hidden",
+			message:   "Field cannot start with _",
+			code:      ErrorCodeNot,
+		},
+		{
+			name:      "any of with a valid validator",
+			validator: &AnyOfValidator{Validators: []Validator{&IpValidator{}, &HostnameValidator{}}},
+			value:     "mc.example.com",
+		},
+		{
+			name:      "any of with failing validators",
+			validator: &AnyOfValidator{Validators: []Validator{&IpValidator{}, &HostnameValidator{}}},
+			value:     "bad_host",
+			message:   "Field has to satisfy one of the following: Field is not a valid IP address or Field is not a valid hostname",
+			code:      ErrorCodeAnyOf,
+		},
+		{
+			name:      "any of without validators",
+			validator: &AnyOfValidator{},
+			value:     "lobby",
+		},
+		{
+			name:      "all of with valid validators",
+			validator: &AllOfValidator{Validators: []Validator{&PrefixValidator{Prefix: "mc-"}, &MaxLengthValidator{MaxLength: 8}}},
+			value:     "mc-lobby",
+		},
+		{
+			name:      "all of keeps the error of a single failing validator",
+			validator: &AllOfValidator{Validators: []Validator{&PrefixValidator{Prefix: "mc-"}, &MaxLengthValidator{MaxLength: 8}}},
+			value:     "lobby",
+			message:   "Field has to start with mc-",
+			code:      ErrorCodePrefix,
+		},
+		{
+			name:      "all of merges the errors of several failing validators",
+			validator: &AllOfValidator{Validators: []Validator{&PrefixValidator{Prefix: "mc-"}, &MaxLengthValidator{MaxLength: 4}}},
+			value:     "survival",
+			message:   "Field has to satisfy all of the following: Field has to start with mc-; Field is too long (length: 8, max length: 4)",
+			code:      ErrorCodeAllOf,
+		},
+		{
+			name:      "when without a condition always applies",
+			validator: &WhenValidator{Validator: &MaxLengthValidator{MaxLength: 4}},
+			value:     "survival",
+			message:   "Field is too long (length: 8, max length: 4)",
+			code:      ErrorCodeMaxLength,
+		},
+		{
+			name:      "when with a condition that is not met",
+			validator: &WhenValidator{Condition: &CustomDisplayCondition{Condition: func(any) bool { return false }}, Validator: &MaxLengthValidator{MaxLength: 4}},
+			value:     "survival",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			field := NewTextField("value", nil, nil, "", "Value", test.value)
+			valid := test.validator.Validate(field)
+			if valid != (test.message == "") {
+				t.Fatalf("%q is valid: %v, expected %v (error: %v)", test.value, valid, test.message == "", field.GetError())
+			}
+			if valid {
+				return
+			}
+			customErr, ok := field.GetError().(*CustomError)
+			if !ok {
+				t.Fatalf("error is %v, expected a *CustomError", field.GetError())
+			}
+			if customErr.Message != test.message || customErr.Code != test.code {
+				t.Errorf("error is %q (code: %s), expected %q (code: %s)", customErr.Message, customErr.Code, test.message, test.code)
+			}
+		})
+	}
+}
+
+func TestNotValidatorKeepsThePreviousError(t *testing.T) {
+	field := NewTextField("value", nil, nil, "", "Value", "steve")
+	previous := &CustomError{Code: ErrorCodeRequired, Message: "Field is required"}
+	field.setError(previous)
+	if !(&NotValidator{Validator: &OneOfValidator{Values: []string{"admin"}}}).Validate(field) {
+		t.Fatalf("steve is not valid")
+	}
+	if field.GetError() != previous {
+		t.Errorf("error is %v, expected the previous error instead of the one of the inner validator", field.GetError())
+	}
+}
+
+func TestWhenValidator(t *testing.T) {
+	tests := []struct {
+		name     string
+		software string
+		valid    bool
+	}{
+		{name: "condition met", software: "paper", valid: false},
+		{name: "condition not met", software: "vanilla", valid: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			software := NewTextField("software", nil, nil, "", "Software", test.software)
+			when := &WhenValidator{Condition: &HasValueDisplayCondition{FieldId: "software", Value: "paper"}, Validator: &NotEmptyValidator{}}
+			plugins := NewTextField("plugins", nil, []Validator{when}, "", "Plugins", "")
+			NewForm(software, plugins)
+			if plugins.IsValid() != test.valid {
+				t.Errorf("plugins is valid: %v, expected %v", plugins.IsValid(), test.valid)
+			}
+			if dependencies := when.GetDependencies(); !slices.Equal(dependencies, []string{"software"}) {
+				t.Errorf("dependencies are %q, expected software", dependencies)
+			}
+		})
+	}
+}
+
+func TestCombinatorDependencies(t *testing.T) {
+	validator := &AnyOfValidator{Validators: []Validator{
+		&NotValidator{Validator: &EqualsFieldValidator{FieldId: "name"}},
+		&AllOfValidator{Validators: []Validator{&LessThanFieldValidator{FieldId: "max"}, &NotEmptyValidator{}}},
+		&WhenValidator{Condition: &NotDisplayCondition{Condition: &HasValueDisplayCondition{FieldId: "mode"}}, Validator: &NotEmptyValidator{}},
+	}}
+	if dependencies := validator.GetDependencies(); !slices.Equal(dependencies, []string{"name", "max", "mode"}) {
+		t.Errorf("dependencies are %q, expected name, max and mode", dependencies)
+	}
+}
+
+func TestNotDisplayCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      string
+		displayed bool
+	}{
+		{name: "condition met", mode: "simple", displayed: false},
+		{name: "condition not met", mode: "advanced", displayed: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mode := NewTextField("mode", nil, nil, "", "Mode", test.mode)
+			extra := NewTextField("extra", []DisplayCondition{&NotDisplayCondition{Condition: &HasValueDisplayCondition{FieldId: "mode", Value: "simple"}}}, nil, "", "Extra", "")
+			form := NewForm(mode, extra)
+			if displayed := slices.Contains(fieldIds(form.GetFieldsToDisplay()), "extra"); displayed != test.displayed {
+				t.Errorf("extra is displayed: %v, expected %v", displayed, test.displayed)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package go_forms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComputedFieldsDiamond(t *testing.T) {
+	a := NewTextField("a", nil, nil, "", "A", "1")
+	// c is declared before b, so it has to wait for b although it comes first in the form
+	c := NewComputedField("c", nil, nil, "C", []string{"a", "b"}, func(values map[string]string) string {
+		return values["a"] + "|" + values["b"]
+	}, true)
+	b := NewComputedField("b", nil, nil, "B", []string{"a"}, func(values map[string]string) string {
+		return values["a"] + "b"
+	}, true)
+	form := NewForm(a, c, b)
+	if c.GetValue() != "1|1b" {
+		t.Fatalf("initial value of c is %q, expected %q", c.GetValue(), "1|1b")
+	}
+	a.SetValue("2")
+	if b.GetValue() != "2b" {
+		t.Errorf("value of b is %q, expected %q", b.GetValue(), "2b")
+	}
+	if c.GetValue() != "2|2b" {
+		t.Errorf("value of c is %q, expected %q", c.GetValue(), "2|2b")
+	}
+	form.Undo()
+	if c.GetValue() != "1|1b" {
+		t.Errorf("value of c after undo is %q, expected %q", c.GetValue(), "1|1b")
+	}
+}
+
+func TestComputedFieldsCycle(t *testing.T) {
+	identity := func(values map[string]string) string { return values["a"] }
+	fields := func() []Field {
+		return []Field{
+			NewTextField("a", nil, nil, "", "A", ""),
+			NewComputedField("x", nil, nil, "X", []string{"a", "z"}, identity, true),
+			NewComputedField("y", nil, nil, "Y", []string{"x"}, identity, true),
+			NewComputedField("z", nil, nil, "Z", []string{"y"}, identity, true),
+		}
+	}
+	_, err := NewCheckedForm(fields()...)
+	if err == nil || !strings.Contains(err.Error(), "x -> z -> y -> x") {
+		t.Errorf("NewCheckedForm returned %v, expected the cycle x -> z -> y -> x", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewForm did not panic")
+		}
+	}()
+	NewForm(fields()...)
+}
@@ -0,0 +1,168 @@
+package go_forms
+
+import (
+	"slices"
+	"sync"
+)
+
+// Threading model:
+// Fields and forms can be read and changed from any goroutine.
+// Changes of values (SetValue, Undo, Redo, ApplyDraft, ...) store the values and record the undo step while the form is locked,
+// so the history always matches the values. Their consequences (computed fields, the hidden field policy, re-validating dependent fields
+// and the callbacks) are applied afterwards without holding the lock, one change after another (see propagate).
+// Validators, display conditions, normalizers and compute functions may therefore change the form themselves, the change is applied
+// after the current one. If another goroutine is applying changes, SetValue returns once the value is stored and the other goroutine
+// applies its consequences.
+// After a change the callbacks (change callback, auto save and renderer updates) are passed to the dispatcher of the form.
+// By default they are called directly on the goroutine that applies the change.
+
+// dispatchDirectly is the default dispatcher calling the callback on the current goroutine
+func dispatchDirectly(callback func()) {
+	callback()
+}
+
+// SetCallbackDispatcher sets the function that is used to deliver the callbacks of the form, e.g. to run them on a specific goroutine.
+// Passing nil restores the default, which calls them directly on the goroutine that made the change.
+func (f *Form) SetCallbackDispatcher(dispatch func(callback func())) {
+	if dispatch == nil {
+		dispatch = dispatchDirectly
+	}
+	f.stateMu.Lock()
+	defer f.stateMu.Unlock()
+	f.dispatch = dispatch
+}
+
+// NewSerialDispatcher returns a dispatcher delivering all callbacks in order on a single goroutine.
+// The goroutine runs until stop is called, callbacks dispatched afterwards are dropped.
+func NewSerialDispatcher() (dispatch func(callback func()), stop func()) {
+	var mu sync.Mutex
+	var queue []func()
+	stopped := false
+	wake := make(chan struct{}, 1)
+	go func() {
+		for range wake {
+			for {
+				mu.Lock()
+				if len(queue) == 0 {
+					mu.Unlock()
+					break
+				}
+				callback := queue[0]
+				queue = queue[1:]
+				mu.Unlock()
+				callback()
+			}
+		}
+	}()
+	dispatch = func(callback func()) {
+		mu.Lock()
+		defer mu.Unlock()
+		if stopped {
+			return
+		}
+		queue = append(queue, callback)
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+	stop = func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if !stopped {
+			stopped = true
+			close(wake)
+		}
+	}
+	return dispatch, stop
+}
+
+// addChangeListener adds a listener that is called after every change and returns a function removing it
+func (f *Form) addChangeListener(listener func()) (remove func()) {
+	f.stateMu.Lock()
+	defer f.stateMu.Unlock()
+	added := &listener
+	f.changeListeners = append(f.changeListeners, added)
+	return func() {
+		f.stateMu.Lock()
+		defer f.stateMu.Unlock()
+		f.changeListeners = slices.DeleteFunc(f.changeListeners, func(listener *func()) bool { return listener == added })
+	}
+}
+
+// pendingChange is a change whose values are stored but whose consequences are not applied yet
+type pendingChange struct {
+	// ids are the fields whose values were stored, all computed fields are computed again if recomputeAll is set
+	ids          []string
+	recomputeAll bool
+	// step is the undo step the changes of the hidden field policy are added to, 0 if they are not recorded (e.g. for Undo)
+	step int
+}
+
+// propagate queues the consequences of a stored change and applies the queued changes unless another call is applying them already,
+// which then applies this change too. The form has to be locked, propagate unlocks it.
+func (f *Form) propagate(change pendingChange) {
+	f.pending = append(f.pending, change)
+	if f.propagating {
+		f.mu.Unlock()
+		return
+	}
+	f.propagating = true
+	for len(f.pending) > 0 {
+		change := f.pending[0]
+		f.pending = f.pending[1:]
+		f.mu.Unlock()
+		f.applyChange(change)
+		f.mu.Lock()
+	}
+	f.propagating = false
+	f.mu.Unlock()
+}
+
+// applyChange updates the computed fields, applies the hidden field policy and notifies the listeners about a stored change,
+// the form must not be locked
+func (f *Form) applyChange(change pendingChange) {
+	changedIds := slices.Clone(change.ids)
+	if change.recomputeAll {
+		changedIds = append(changedIds, f.recomputeAll()...)
+	} else {
+		changedIds = append(changedIds, f.updateComputedFields(change.ids...)...)
+	}
+	policyChanges, policyIds := f.applyHiddenFieldPolicy()
+	if change.step != 0 && len(policyChanges) > 0 {
+		f.mu.Lock()
+		f.history.extend(change.step, policyChanges)
+		f.mu.Unlock()
+	}
+	f.afterChange(append(changedIds, policyIds...))
+}
+
+// afterChange starts the asynchronous validation of the given fields, re-validates the fields depending on them and delivers the change
+// callbacks and the validation callbacks of the re-validated fields, the form must not be locked
+func (f *Form) afterChange(changedIds []string) {
+	for _, id := range changedIds {
+		if base := getFieldBase(f.lookupField(id)); base != nil {
+			base.startAsyncValidation(base.Debounce)
+		}
+	}
+	revalidatedIds := f.revalidateDependents(changedIds)
+	f.notifyChange()
+	for _, id := range revalidatedIds {
+		f.notifyValidation(id)
+	}
+}
+
+func (f *Form) notifyChange() {
+	f.stateMu.RLock()
+	onChange := f.onChange
+	listeners := slices.Clone(f.changeListeners)
+	dispatch := f.dispatch
+	f.stateMu.RUnlock()
+	dispatch(func() {
+		onChange()
+		for _, listener := range listeners {
+			(*listener)()
+		}
+		f.triggerAutoSave()
+	})
+}
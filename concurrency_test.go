@@ -0,0 +1,137 @@
+package go_forms
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// runWithTimeout fails the test if run does not return in time, e.g. because a change deadlocks
+func runWithTimeout(t *testing.T, run func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("change did not return, the form is deadlocked")
+	}
+}
+
+// slugValidator sets the slug field while the field it depends on is re-validated
+type slugValidator struct {
+	slug *TextField
+}
+
+func (v *slugValidator) GetDependencies() []string {
+	return []string{"name"}
+}
+
+func (v *slugValidator) Validate(field any) bool {
+	v.slug.SetValue(getFieldBase(field).form.lookupField("name").GetValue() + "-slug")
+	return true
+}
+
+func TestChangesFromCallbacksAreApplied(t *testing.T) {
+	name := NewTextField("name", nil, nil, "", "Name", "")
+	slug := NewTextField("slug", nil, nil, "", "Slug", "")
+	length := NewComputedField("length", nil, nil, "Length", []string{"slug"}, func(values map[string]string) string {
+		return values["slug"] + "!"
+	}, true)
+	confirm := NewTextField("confirm", nil, []Validator{&slugValidator{slug: slug}}, "", "Confirm", "")
+	form := NewForm(name, slug, length, confirm)
+	form.SetOnChangeCallback(func() {
+		if slug.GetValue() == "lobby-slug" {
+			confirm.SetValue("lobby")
+		}
+	})
+	runWithTimeout(t, func() {
+		name.SetValue("lobby")
+	})
+	values := form.GetAllFieldValues()
+	if values["slug"] != "lobby-slug" || values["length"] != "lobby-slug!" || values["confirm"] != "lobby" {
+		t.Errorf("values are %v, expected the changes of the callbacks to be applied", values)
+	}
+}
+
+func TestDisplayConditionsMayChangeTheForm(t *testing.T) {
+	extra := NewTextField("extra", nil, nil, "", "Extra", "")
+	counter := NewTextField("counter", nil, nil, "", "Counter", "")
+	extra.DisplayConditions = []DisplayCondition{&CustomDisplayCondition{Condition: func(field any) bool {
+		counter.SetValue("evaluated")
+		return true
+	}}}
+	form := NewForm(NewTextField("name", nil, nil, "", "Name", ""), extra, counter)
+	runWithTimeout(t, func() {
+		form.SetHiddenFieldPolicy(ClearHiddenValues)
+		form.lookupField("name").SetValue("lobby")
+	})
+	if counter.GetValue() != "evaluated" {
+		t.Errorf("counter is %q, expected the change of the display condition", counter.GetValue())
+	}
+}
+
+func TestConcurrentChanges(t *testing.T) {
+	a := NewTextField("a", nil, nil, "", "A", "")
+	b := NewTextField("b", nil, nil, "", "B", "")
+	sum := NewComputedField("sum", nil, nil, "Sum", []string{"a", "b"}, func(values map[string]string) string {
+		return values["a"] + values["b"]
+	}, true)
+	form := NewForm(a, b, sum)
+	runWithTimeout(t, func() {
+		var wg sync.WaitGroup
+		for _, field := range []*TextField{a, b} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for _, value := range []string{"1", "2", "3"} {
+					field.SetValue(value)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+	if sum.GetValue() != "33" {
+		t.Errorf("sum is %q, expected %q", sum.GetValue(), "33")
+	}
+	for form.Undo() {
+	}
+	if values := form.GetAllFieldValues(); values["a"] != "" || values["b"] != "" || values["sum"] != "" {
+		t.Errorf("values after undoing everything are %v", values)
+	}
+}
+
+func TestConcurrentResets(t *testing.T) {
+	a := NewTextField("a", nil, nil, "", "A", "")
+	b := NewTextField("b", nil, nil, "", "B", "")
+	form := NewForm(a, b)
+	runWithTimeout(t, func() {
+		var wg sync.WaitGroup
+		for _, field := range []*TextField{a, b} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for _, value := range []string{"1", "2", "3", "4", "5"} {
+					field.SetValue(value)
+				}
+			}()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 5 {
+				form.Reset()
+			}
+		}()
+		wg.Wait()
+	})
+	// Every step recorded the values it replaced, so undoing everything restores the empty values
+	for form.Undo() {
+	}
+	if values := form.GetAllFieldValues(); values["a"] != "" || values["b"] != "" {
+		t.Errorf("values after undoing everything are %v", values)
+	}
+}
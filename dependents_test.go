@@ -0,0 +1,60 @@
+package go_forms
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDependentFieldsAreRevalidated(t *testing.T) {
+	password := NewTextField("password", nil, nil, "", "Password", "")
+	confirm := NewTextField("confirm", nil, []Validator{&EqualsFieldValidator{FieldId: "password"}}, "", "Confirm", "")
+	form := NewForm(password, confirm)
+	if ids := fieldIds(form.GetDependentFields("password")); !slices.Equal(ids, []string{"confirm"}) {
+		t.Fatalf("dependent fields of password are %q, expected confirm", ids)
+	}
+	var notified []string
+	form.addValidationListener(func(fieldId string) {
+		notified = append(notified, fieldId)
+	})
+	confirm.SetValue("secret")
+	password.SetValue("secret")
+	if confirm.GetError() != nil {
+		t.Errorf("confirm has the error %v after the password was set to the same value", confirm.GetError())
+	}
+	password.SetValue("other")
+	if confirm.GetError() == nil {
+		t.Errorf("confirm has no error after the password changed")
+	}
+	if !slices.Equal(notified, []string{"confirm", "confirm"}) {
+		t.Errorf("validation listener was notified for %q, expected confirm twice", notified)
+	}
+}
+
+func TestTerminalAsksInvalidDependentsAgain(t *testing.T) {
+	form := NewForm(
+		NewNumberField("min", nil, []Validator{&LessThanFieldValidator{FieldId: "max"}}, "", "Min", 0),
+		NewNumberField("max", nil, nil, "", "Max", 0),
+	)
+	form.GetAllFields()[1].SetValue("")
+	var out bytes.Buffer
+	values, err := FormToTerminal(form, strings.NewReader("5\n3\n2\n"), &out)
+	if err != nil {
+		t.Fatalf("FormToTerminal returned %v (output: %s)", err, out.String())
+	}
+	if values["min"] != "2" || values["max"] != "3" {
+		t.Errorf("values are %v, expected min 2 and max 3", values)
+	}
+	if !strings.Contains(out.String(), "min is not valid anymore") {
+		t.Errorf("output does not explain why min is asked again: %s", out.String())
+	}
+}
+
+func fieldIds(fields []Field) []string {
+	var ids []string
+	for _, field := range fields {
+		ids = append(ids, field.GetId())
+	}
+	return ids
+}
@@ -0,0 +1,145 @@
+package go_forms
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DraftVersion is the version of the draft format written by SaveDraft
+const DraftVersion = 1
+
+// Draft is a snapshot of the values of a partially filled form
+type Draft struct {
+	Version int               `json:"version"`
+	Values  map[string]string `json:"values"`
+	Page    string            `json:"page,omitempty"`
+}
+
+// UnknownFieldsError is returned by RestoreDraft if the draft contains values for fields or a page that do not exist in the form (anymore)
+type UnknownFieldsError struct {
+	FieldIds []string
+	Page     string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	switch {
+	case e.Page == "":
+		return "Draft contains unknown fields (" + strings.Join(e.FieldIds, ", ") + ")"
+	case len(e.FieldIds) == 0:
+		return "Draft contains an unknown page (" + e.Page + ")"
+	}
+	return "Draft contains unknown fields (" + strings.Join(e.FieldIds, ", ") + ") and an unknown page (" + e.Page + ")"
+}
+
+// GetDraft returns a snapshot of the values of all fields (including the ones nested in groups) and the current wizard page.
+// Messages are left out because their text is not entered by the user. Like GetAllFieldValues it leaves out hidden fields if the
+// hidden field policy is ExcludeHiddenValues.
+func (f *Form) GetDraft() *Draft {
+	return f.newDraft(f.GetAllFieldValues())
+}
+
+// snapshot returns a draft of the values of all fields regardless of the hidden field policy, restoring it with ApplyDraft
+// also restores the values of hidden fields
+func (f *Form) snapshot() *Draft {
+	return f.newDraft(f.getAllFieldValues())
+}
+
+func (f *Form) newDraft(values map[string]string) *Draft {
+	for _, field := range f.GetAllFields() {
+		if _, ok := field.(*Message); ok {
+			delete(values, field.GetId())
+		}
+	}
+	draft := &Draft{Version: DraftVersion, Values: values}
+	if page := f.GetCurrentPage(); page != nil {
+		draft.Page = page.Id
+	}
+	return draft
+}
+
+// SaveDraft returns the draft of the form encoded as JSON
+func (f *Form) SaveDraft() ([]byte, error) {
+	return json.Marshal(f.GetDraft())
+}
+
+// RestoreDraft restores the values and the wizard page from a draft created with SaveDraft.
+// The values of all known fields are restored even if an *UnknownFieldsError is returned for the others.
+func (f *Form) RestoreDraft(data []byte) error {
+	var draft Draft
+	if err := json.Unmarshal(data, &draft); err != nil {
+		return err
+	}
+	return f.ApplyDraft(&draft)
+}
+
+// ApplyDraft restores the values and the wizard page from the given draft (see RestoreDraft).
+// The values are normalized like in SetValue, values of messages (e.g. in drafts of older versions) are ignored.
+// The restore is one undo step and triggers the auto save once.
+func (f *Form) ApplyDraft(draft *Draft) error {
+	if draft.Version < 1 || draft.Version > DraftVersion {
+		return &CustomError{Message: "Unsupported draft version (version: " + strconv.Itoa(draft.Version) + ", supported version: " + strconv.Itoa(DraftVersion) + ")"}
+	}
+	// The values are normalized before the form is locked, normalizers may change the form themselves
+	var unknownFieldIds []string
+	values := make(map[*FieldBaseType]string)
+	for id, value := range draft.Values {
+		field := f.lookupField(id)
+		base := getFieldBase(field)
+		if base == nil {
+			unknownFieldIds = append(unknownFieldIds, id)
+			continue
+		}
+		switch field.(type) {
+		case *FieldGroup, *Message:
+			continue
+		}
+		values[base] = base.normalize(value)
+	}
+	page, unknownPage := -1, ""
+	if draft.Page != "" && f.IsWizard() {
+		if page = f.pageIndex(draft.Page); page == -1 {
+			unknownPage = draft.Page
+		}
+	}
+	var changes []valueChange
+	var changedIds []string
+	f.mu.Lock()
+	if page != -1 {
+		// The draft is saved once when the change is propagated
+		f.storeCurrentPageIndex(page)
+	}
+	for base, value := range values {
+		if oldValue := base.storeValue(value); oldValue != value {
+			changes = append(changes, valueChange{fieldId: base.Id, oldValue: oldValue, newValue: value})
+			changedIds = append(changedIds, base.Id)
+		}
+	}
+	step := f.history.record(changes)
+	f.propagate(pendingChange{ids: changedIds, recomputeAll: true, step: step})
+	if len(unknownFieldIds) > 0 || unknownPage != "" {
+		slices.Sort(unknownFieldIds)
+		return &UnknownFieldsError{FieldIds: unknownFieldIds, Page: unknownPage}
+	}
+	return nil
+}
+
+// SetAutoSaveCallback sets a callback that gets the JSON encoded draft after every change of the form (nil disables auto save)
+func (f *Form) SetAutoSaveCallback(autoSave func(draft []byte)) {
+	f.stateMu.Lock()
+	defer f.stateMu.Unlock()
+	f.autoSave = autoSave
+}
+
+func (f *Form) triggerAutoSave() {
+	f.stateMu.RLock()
+	autoSave := f.autoSave
+	f.stateMu.RUnlock()
+	if autoSave == nil {
+		return
+	}
+	if data, err := f.SaveDraft(); err == nil {
+		autoSave(data)
+	}
+}
@@ -0,0 +1,143 @@
+package go_forms
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func newDraftWizard() *Form {
+	name := NewTextField("name", nil, nil, "", "Name", "")
+	name.Normalizers = []Normalizer{&TrimNormalizer{}}
+	return NewWizard(
+		NewWizardPage("general", "General", nil, NewMessage("intro", nil, "Welcome"), name),
+		NewWizardPage("network", "Network", nil, NewNumberField("port", nil, nil, "", "Port", 25565)),
+	)
+}
+
+func TestDraftRoundTrip(t *testing.T) {
+	form := newDraftWizard()
+	form.lookupField("name").SetValue("lobby")
+	form.lookupField("port").SetValue("25566")
+	form.SetCurrentPage("network")
+	data, err := form.SaveDraft()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var draft Draft
+	if err := json.Unmarshal(data, &draft); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := draft.Values["intro"]; ok {
+		t.Errorf("draft contains the message intro: %s", data)
+	}
+
+	restored := newDraftWizard()
+	if err := restored.RestoreDraft(data); err != nil {
+		t.Fatalf("RestoreDraft returned %v", err)
+	}
+	if values := restored.GetAllFieldValues(); !maps.Equal(values, form.GetAllFieldValues()) {
+		t.Errorf("restored values are %v, expected %v", values, form.GetAllFieldValues())
+	}
+	if page := restored.GetCurrentPage().Id; page != "network" {
+		t.Errorf("restored page is %q, expected network", page)
+	}
+	if !restored.Undo() || restored.lookupField("name").GetValue() != "" {
+		t.Errorf("restoring the draft cannot be undone in one step")
+	}
+}
+
+func TestApplyDraft(t *testing.T) {
+	tests := []struct {
+		name    string
+		draft   Draft
+		values  map[string]string
+		unknown *UnknownFieldsError
+		err     bool
+	}{
+		{
+			name:   "normalizes values",
+			draft:  Draft{Version: 1, Values: map[string]string{"name": "  lobby  "}},
+			values: map[string]string{"intro": "Welcome", "name": "lobby", "port": "25565"},
+		},
+		{
+			name:   "keeps the current message text",
+			draft:  Draft{Version: 1, Values: map[string]string{"intro": "Old welcome"}},
+			values: map[string]string{"intro": "Welcome", "name": "", "port": "25565"},
+		},
+		{
+			name:    "reports unknown fields and pages",
+			draft:   Draft{Version: 1, Values: map[string]string{"name": "lobby", "motd": "Hi"}, Page: "plugins"},
+			values:  map[string]string{"intro": "Welcome", "name": "lobby", "port": "25565"},
+			unknown: &UnknownFieldsError{FieldIds: []string{"motd"}, Page: "plugins"},
+		},
+		{
+			name:   "rejects newer versions",
+			draft:  Draft{Version: DraftVersion + 1, Values: map[string]string{"name": "lobby"}},
+			values: map[string]string{"intro": "Welcome", "name": "", "port": "25565"},
+			err:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			form := newDraftWizard()
+			err := form.ApplyDraft(&test.draft)
+			var unknown *UnknownFieldsError
+			switch {
+			case test.unknown != nil:
+				if !errors.As(err, &unknown) || unknown.Page != test.unknown.Page || !slices.Equal(unknown.FieldIds, test.unknown.FieldIds) {
+					t.Errorf("ApplyDraft returned %v, expected %v", err, test.unknown)
+				}
+			case test.err:
+				if err == nil {
+					t.Errorf("ApplyDraft returned no error")
+				}
+			case err != nil:
+				t.Errorf("ApplyDraft returned %v", err)
+			}
+			if values := form.getAllFieldValues(); !maps.Equal(values, test.values) {
+				t.Errorf("values are %v, expected %v", values, test.values)
+			}
+		})
+	}
+}
+
+func TestApplyDraftSavesOnce(t *testing.T) {
+	form := newDraftWizard()
+	saves := 0
+	form.SetAutoSaveCallback(func(_ []byte) {
+		saves++
+	})
+	if err := form.ApplyDraft(&Draft{Version: 1, Values: map[string]string{"name": "lobby"}, Page: "network"}); err != nil {
+		t.Fatal(err)
+	}
+	if saves != 1 {
+		t.Errorf("draft was saved %d times, expected once", saves)
+	}
+	saves = 0
+	form.Reset()
+	if saves != 1 {
+		t.Errorf("draft was saved %d times by Reset, expected once", saves)
+	}
+}
+
+func TestUnknownFieldsErrorMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     *UnknownFieldsError
+		message string
+	}{
+		{name: "fields", err: &UnknownFieldsError{FieldIds: []string{"motd", "seed"}}, message: "Draft contains unknown fields (motd, seed)"},
+		{name: "page", err: &UnknownFieldsError{Page: "plugins"}, message: "Draft contains an unknown page (plugins)"},
+		{name: "fields and page", err: &UnknownFieldsError{FieldIds: []string{"motd"}, Page: "plugins"}, message: "Draft contains unknown fields (motd) and an unknown page (plugins)"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if message := test.err.Error(); message != test.message {
+				t.Errorf("message is %q, expected %q", message, test.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package go_forms
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Exporting forms as schema, the reverse of Schema.NewForm
+
+// ToSchema describes the form as schema, including help texts and the descriptions of options.
+// It returns a *SchemaError if the form uses something a schema cannot describe, e.g. computed fields, asynchronous validators
+// or custom validators, display conditions, normalizers and default functions. Path points to the part like for Schema.NewForm.
+func (f *Form) ToSchema() (*Schema, error) {
+	schema := &Schema{
+		Version:        SchemaVersion,
+		ValidationMode: validationModeNames[f.GetValidationMode()],
+		HiddenValues:   hiddenFieldPolicyNames[f.GetHiddenFieldPolicy()],
+	}
+	if !f.IsWizard() {
+		fields, err := exportFields("fields", f.Fields)
+		if err != nil {
+			return nil, err
+		}
+		schema.Fields = fields
+		return schema, nil
+	}
+	for index, page := range f.pages {
+		path := "pages[" + strconv.Itoa(index) + "]"
+		displayConditions, err := exportConditions(path+".display_conditions", page.DisplayConditions)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := exportFields(path+".fields", page.Fields)
+		if err != nil {
+			return nil, err
+		}
+		schema.Pages = append(schema.Pages, PageSchema{Id: page.Id, Title: page.Title, DisplayConditions: displayConditions, Fields: fields})
+	}
+	return schema, nil
+}
+
+// SaveSchema returns the schema of the form encoded as indented JSON, it can be loaded again with LoadSchema (see ToSchema)
+func (f *Form) SaveSchema() ([]byte, error) {
+	schema, err := f.ToSchema()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// The names of the defaults are left out, like in hand written schemas
+var validationModeNames = map[ValidationMode]string{ValidateOnBlur: "blur", ValidateOnSubmit: "submit"}
+
+var hiddenFieldPolicyNames = map[HiddenFieldPolicy]string{ExcludeHiddenValues: "exclude", ClearHiddenValues: "clear", ResetHiddenValues: "reset"}
+
+var groupLayoutNames = map[GroupLayout]string{GroupLayoutCard: "card", GroupLayoutTab: "tab", GroupLayoutAccordion: "accordion", GroupLayoutGrid: "grid"}
+
+var lengthUnitNames = map[LengthUnit]string{LengthInGraphemes: "graphemes", LengthInBytes: "bytes"}
+
+func exportFields(path string, fields []Field) ([]FieldSchema, error) {
+	fieldSchemas := make([]FieldSchema, 0, len(fields))
+	for index, field := range fields {
+		fieldSchema, err := exportField(path+"["+strconv.Itoa(index)+"]", field)
+		if err != nil {
+			return nil, err
+		}
+		fieldSchemas = append(fieldSchemas, fieldSchema)
+	}
+	return fieldSchemas, nil
+}
+
+func exportField(path string, field Field) (FieldSchema, error) {
+	base := getFieldBase(field)
+	fieldSchema := FieldSchema{
+		Id:               base.Id,
+		Help:             base.HelpText,
+		Default:          base.Default,
+		DefaultEnv:       base.defaultEnv,
+		Required:         base.Required,
+		ReadOnly:         base.ReadOnly,
+		ValidateDisabled: base.DisabledValidation == ValidateDisabled,
+	}
+	if base.DefaultFunc != nil && base.defaultEnv == "" {
+		return FieldSchema{}, &SchemaError{Path: path + ".default", Message: "default functions cannot be described by a schema"}
+	}
+	if len(base.AsyncValidators) > 0 {
+		return FieldSchema{}, &SchemaError{Path: path, Message: "asynchronous validators cannot be described by a schema"}
+	}
+	switch field := field.(type) {
+	case *ComputedField:
+		return FieldSchema{}, &SchemaError{Path: path + ".type", Message: "computed fields cannot be described by a schema"}
+	case *TextField:
+		fieldSchema.Type = "text"
+		fieldSchema.Placeholder, fieldSchema.Prompt = field.Placeholder, field.Prompt
+	case *NumberField:
+		fieldSchema.Type = "number"
+		fieldSchema.Placeholder, fieldSchema.Prompt = field.Placeholder, field.Prompt
+	case *MultipleChoiceField:
+		fieldSchema.Type = "choice"
+		fieldSchema.Placeholder, fieldSchema.Prompt = field.Placeholder, field.Prompt
+		for _, key := range sortedOptionKeys(field) {
+			option := field.Options[key]
+			fieldSchema.Options = append(fieldSchema.Options, OptionSchema{Key: key, Label: option.Label, Description: option.Description})
+		}
+	case *Message:
+		fieldSchema.Type = "message"
+		fieldSchema.Message = field.GetValue()
+	case *FieldGroup:
+		fields, err := exportFields(path+".fields", field.Fields)
+		if err != nil {
+			return FieldSchema{}, err
+		}
+		fieldSchema.Type = "group"
+		fieldSchema.Heading = field.heading
+		fieldSchema.Layout = groupLayoutNames[field.Layout]
+		fieldSchema.Columns = field.Columns
+		fieldSchema.Fields = fields
+	default:
+		return FieldSchema{}, &SchemaError{Path: path + ".type", Message: fmt.Sprintf("field type %T cannot be described by a schema", field)}
+	}
+	var err error
+	if fieldSchema.DisplayConditions, err = exportConditions(path+".display_conditions", base.DisplayConditions); err != nil {
+		return FieldSchema{}, err
+	}
+	if fieldSchema.RequiredIf, err = exportConditions(path+".required_if", base.RequiredIf); err != nil {
+		return FieldSchema{}, err
+	}
+	if fieldSchema.DisableConditions, err = exportConditions(path+".disable_conditions", base.DisableConditions); err != nil {
+		return FieldSchema{}, err
+	}
+	if fieldSchema.Validators, err = exportValidators(path+".validators", base.Validators); err != nil {
+		return FieldSchema{}, err
+	}
+	if fieldSchema.Normalizers, err = exportNormalizers(path+".normalizers", base.Normalizers); err != nil {
+		return FieldSchema{}, err
+	}
+	return fieldSchema, nil
+}
+
+func exportValidators(path string, validators []Validator) ([]ValidatorSchema, error) {
+	var validatorSchemas []ValidatorSchema
+	for index, validator := range validators {
+		validatorSchema, err := exportValidator(path+"["+strconv.Itoa(index)+"]", validator)
+		if err != nil {
+			return nil, err
+		}
+		validatorSchemas = append(validatorSchemas, validatorSchema)
+	}
+	return validatorSchemas, nil
+}
+
+func exportValidator(path string, validator Validator) (ValidatorSchema, error) {
+	switch v := validator.(type) {
+	case *NotEmptyValidator:
+		return ValidatorSchema{Type: ErrorCodeNotEmpty}, nil
+	case *MinLengthValidator:
+		return ValidatorSchema{Type: ErrorCodeMinLength, Min: v.MinLength, Unit: lengthUnitNames[v.Unit]}, nil
+	case *MaxLengthValidator:
+		return ValidatorSchema{Type: ErrorCodeMaxLength, Max: v.MaxLength, Unit: lengthUnitNames[v.Unit]}, nil
+	case *IpValidator:
+		return ValidatorSchema{Type: ErrorCodeIp}, nil
+	case *Ipv4Validator:
+		return ValidatorSchema{Type: ErrorCodeIpv4}, nil
+	case *Ipv6Validator:
+		return ValidatorSchema{Type: ErrorCodeIpv6}, nil
+	case *RegexValidator:
+		return ValidatorSchema{Type: ErrorCodeRegex, Pattern: v.RegexPattern}, nil
+	case *UrlValidator:
+		return ValidatorSchema{Type: ErrorCodeUrl, Values: v.AllowedSchemes}, nil
+	case *EmailValidator:
+		return ValidatorSchema{Type: ErrorCodeEmail}, nil
+	case *HostnameValidator:
+		return ValidatorSchema{Type: ErrorCodeHostname}, nil
+	case *HostPortValidator:
+		return ValidatorSchema{Type: ErrorCodeHostPort}, nil
+	case *CidrValidator:
+		return ValidatorSchema{Type: ErrorCodeCidr}, nil
+	case *MacAddressValidator:
+		return ValidatorSchema{Type: ErrorCodeMacAddress}, nil
+	case *UuidValidator:
+		return ValidatorSchema{Type: ErrorCodeUuid}, nil
+	case *SemVerValidator:
+		return ValidatorSchema{Type: ErrorCodeSemVer}, nil
+	case *PortValidator:
+		return ValidatorSchema{Type: ErrorCodePort, Min: v.Min, Max: v.Max}, nil
+	case *OneOfValidator:
+		return ValidatorSchema{Type: ErrorCodeOneOf, Values: v.Values}, nil
+	case *NotOneOfValidator:
+		return ValidatorSchema{Type: ErrorCodeNotOneOf, Values: v.Values}, nil
+	case *PrefixValidator:
+		return ValidatorSchema{Type: ErrorCodePrefix, Value: v.Prefix}, nil
+	case *SuffixValidator:
+		return ValidatorSchema{Type: ErrorCodeSuffix, Value: v.Suffix}, nil
+	case *ContainsValidator:
+		return ValidatorSchema{Type: ErrorCodeContains, Value: v.Substring}, nil
+	case *IsIntegerValidator:
+		return ValidatorSchema{Type: ErrorCodeInteger}, nil
+	case *MinValidator:
+		return ValidatorSchema{Type: ErrorCodeMin, Min: v.Min}, nil
+	case *MaxValidator:
+		return ValidatorSchema{Type: ErrorCodeMax, Max: v.Max}, nil
+	case *ChoiceValidator:
+		return ValidatorSchema{Type: ErrorCodeChoice}, nil
+	case *AllFieldsValid:
+		return ValidatorSchema{Type: ErrorCodeAllFieldsValid}, nil
+	case *IsValidValidator:
+		return ValidatorSchema{Type: ErrorCodeIsValid, Fields: v.FieldIds}, nil
+	case *EqualsFieldValidator:
+		return ValidatorSchema{Type: ErrorCodeEqualsField, Field: v.FieldId}, nil
+	case *NotEqualsFieldValidator:
+		return ValidatorSchema{Type: ErrorCodeNotEqualsField, Field: v.FieldId}, nil
+	case *GreaterThanFieldValidator:
+		return ValidatorSchema{Type: ErrorCodeGreaterThanField, Field: v.FieldId, Layout: v.Layout}, nil
+	case *LessThanFieldValidator:
+		return ValidatorSchema{Type: ErrorCodeLessThanField, Field: v.FieldId, Layout: v.Layout}, nil
+	case *RequiredIfValidator:
+		return ValidatorSchema{Type: ErrorCodeRequiredIf, Field: v.FieldId, Value: v.Value}, nil
+	case *RequiredUnlessValidator:
+		return ValidatorSchema{Type: ErrorCodeRequiredUnless, Field: v.FieldId, Value: v.Value}, nil
+	case *MutuallyExclusiveValidator:
+		return ValidatorSchema{Type: ErrorCodeMutuallyExclusive, Fields: v.FieldIds}, nil
+	case *ExpressionValidator:
+		return ValidatorSchema{Type: ErrorCodeExpression, Expression: v.Expression.String(), Message: v.Message}, nil
+	case *NotValidator:
+		validatorSchema, err := exportValidator(path+".validators[0]", v.Validator)
+		if err != nil {
+			return ValidatorSchema{}, err
+		}
+		return ValidatorSchema{Type: ErrorCodeNot, Validators: []ValidatorSchema{validatorSchema}, Message: v.Message}, nil
+	case *AnyOfValidator:
+		validatorSchemas, err := exportValidators(path+".validators", v.Validators)
+		return ValidatorSchema{Type: ErrorCodeAnyOf, Validators: validatorSchemas}, err
+	case *AllOfValidator:
+		validatorSchemas, err := exportValidators(path+".validators", v.Validators)
+		return ValidatorSchema{Type: ErrorCodeAllOf, Validators: validatorSchemas}, err
+	case *WhenValidator:
+		condition, err := exportCondition(path+".condition", v.Condition)
+		if err != nil {
+			return ValidatorSchema{}, err
+		}
+		validatorSchema, err := exportValidator(path+".validators[0]", v.Validator)
+		if err != nil {
+			return ValidatorSchema{}, err
+		}
+		return ValidatorSchema{Type: "when", Condition: &condition, Validators: []ValidatorSchema{validatorSchema}}, nil
+	default:
+		return ValidatorSchema{}, &SchemaError{Path: path, Message: fmt.Sprintf("validator %T cannot be described by a schema", validator)}
+	}
+}
+
+func exportConditions(path string, conditions []DisplayCondition) ([]ConditionSchema, error) {
+	var conditionSchemas []ConditionSchema
+	for index, condition := range conditions {
+		conditionSchema, err := exportCondition(path+"["+strconv.Itoa(index)+"]", condition)
+		if err != nil {
+			return nil, err
+		}
+		conditionSchemas = append(conditionSchemas, conditionSchema)
+	}
+	return conditionSchemas, nil
+}
+
+func exportCondition(path string, condition DisplayCondition) (ConditionSchema, error) {
+	switch c := condition.(type) {
+	case *AlwaysDisplay:
+		return ConditionSchema{Type: "always"}, nil
+	case *HasValueDisplayCondition:
+		return ConditionSchema{Type: "has_value", Field: c.FieldId, Value: c.Value}, nil
+	case *IsValidDisplayCondition:
+		return ConditionSchema{Type: "is_valid", Fields: c.FieldIds}, nil
+	case *IsInvalidDisplayCondition:
+		return ConditionSchema{Type: "is_invalid", Fields: c.FieldIds}, nil
+	case *AllFieldsValidDisplayCondition:
+		return ConditionSchema{Type: "all_fields_valid"}, nil
+	case *DisplayAfter:
+		return ConditionSchema{Type: "display_after", Field: c.FieldId}, nil
+	case *AndDisplayCondition:
+		conditionSchemas, err := exportConditions(path+".conditions", c.Conditions)
+		return ConditionSchema{Type: "and", Conditions: conditionSchemas}, err
+	case *OrDisplayCondition:
+		conditionSchemas, err := exportConditions(path+".conditions", c.Conditions)
+		return ConditionSchema{Type: "or", Conditions: conditionSchemas}, err
+	case *NotDisplayCondition:
+		conditionSchema, err := exportCondition(path+".condition", c.Condition)
+		if err != nil {
+			return ConditionSchema{}, err
+		}
+		return ConditionSchema{Type: "not", Condition: &conditionSchema}, nil
+	case *ExpressionDisplayCondition:
+		return ConditionSchema{Type: "expression", Expression: c.Expression.String()}, nil
+	default:
+		return ConditionSchema{}, &SchemaError{Path: path, Message: fmt.Sprintf("display condition %T cannot be described by a schema", condition)}
+	}
+}
+
+func exportNormalizers(path string, normalizers []Normalizer) ([]string, error) {
+	var names []string
+	for index, normalizer := range normalizers {
+		var name string
+		switch normalizer.(type) {
+		case *TrimNormalizer:
+			name = "trim"
+		case *CollapseWhitespaceNormalizer:
+			name = "collapse_whitespace"
+		case *NfcNormalizer:
+			name = "nfc"
+		case *LowerCaseNormalizer:
+			name = "lower_case"
+		case *UpperCaseNormalizer:
+			name = "upper_case"
+		default:
+			return nil, &SchemaError{Path: path + "[" + strconv.Itoa(index) + "]", Message: fmt.Sprintf("normalizer %T cannot be described by a schema", normalizer)}
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
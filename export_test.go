@@ -0,0 +1,106 @@
+package go_forms
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const exportSchema = `{
+  "version": 1,
+  "validation_mode": "blur",
+  "hidden_values": "exclude",
+  "pages": [
+    {"id": "general", "title": "General", "fields": [
+      {"type": "message", "id": "intro", "message": "Welcome"},
+      {"type": "text", "id": "name", "prompt": "Name", "help": "Shown in the server list", "required": true, "normalizers": ["trim"],
+       "validators": [{"type": "max_length", "max": 16, "unit": "graphemes"}, {"type": "expression", "expression": "name != \"admin\"", "message": "Reserved"}]},
+      {"type": "choice", "id": "software", "prompt": "Software", "default": "paper", "options": [
+        {"key": "paper", "label": "Paper", "description": "Fast and plugin friendly"},
+        {"key": "vanilla", "label": "Vanilla"}
+      ]}
+    ]},
+    {"id": "network", "title": "Network", "display_conditions": [{"type": "not", "condition": {"type": "has_value", "field": "name", "value": ""}}], "fields": [
+      {"type": "group", "id": "ports", "heading": "Ports", "layout": "grid", "columns": 2, "fields": [
+        {"type": "number", "id": "port", "prompt": "Port", "default": "25565", "default_env": "EXPORT_TEST_PORT", "validators": [{"type": "port"}]},
+        {"type": "number", "id": "query", "prompt": "Query port", "default": "0", "required_if": [{"type": "is_valid", "fields": ["port"]}],
+         "validators": [{"type": "when", "condition": {"type": "always"}, "validators": [{"type": "not_equals_field", "field": "port"}]}]}
+      ]}
+    ]}
+  ]
+}`
+
+func TestSchemaRoundTrip(t *testing.T) {
+	schema, err := ParseSchema([]byte(exportSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	form, err := schema.NewForm()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported, err := form.ToSchema()
+	if err != nil {
+		t.Fatalf("ToSchema returned %v", err)
+	}
+	if !reflect.DeepEqual(exported, schema) {
+		t.Errorf("exported schema is %+v, expected %+v", exported, schema)
+	}
+	data, err := form.SaveSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSchema(data); err != nil {
+		t.Errorf("LoadSchema of the saved schema returned %v", err)
+	}
+}
+
+func TestSchemaExportErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		form func() *Form
+		path string
+	}{
+		{
+			name: "computed field",
+			form: func() *Form {
+				return NewForm(NewComputedField("slug", nil, nil, "Slug", nil, func(map[string]string) string { return "" }, true))
+			},
+			path: "fields[0].type",
+		},
+		{
+			name: "custom validator in a group",
+			form: func() *Form {
+				return NewForm(NewFieldGroup("network", nil, nil, "Network",
+					NewTextField("host", nil, []Validator{&NotEmptyValidator{}, &CustomValidator{}}, "", "Host", ""),
+				))
+			},
+			path: "fields[0].fields[0].validators[1]",
+		},
+		{
+			name: "custom display condition",
+			form: func() *Form {
+				return NewForm(NewTextField("host", []DisplayCondition{&NotDisplayCondition{Condition: &CustomDisplayCondition{}}}, nil, "", "Host", ""))
+			},
+			path: "fields[0].display_conditions[0].condition",
+		},
+		{
+			name: "default function",
+			form: func() *Form {
+				host := NewTextField("host", nil, nil, "", "Host", "")
+				host.DefaultFunc = func(map[string]string) string { return "localhost" }
+				return NewForm(host)
+			},
+			path: "fields[0].default",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.form().ToSchema()
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) || schemaErr.Path != test.path {
+				t.Errorf("ToSchema returned %v, expected a *SchemaError at %s", err, test.path)
+			}
+		})
+	}
+}
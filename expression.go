@@ -0,0 +1,1050 @@
+package go_forms
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Defining the expression language for display conditions and validators
+//
+// Expressions like `software == "paper" && ram >= 4096` refer to fields by their id and support
+// numbers, strings, booleans, the operators || && == != < <= > >= + - * / % ! and the functions
+// len(string), visible("id") and valid("id"). Values of number fields are numbers, all other fields are strings.
+// Empty number fields have no value (nil), which makes every operation using them have no value, except for || and && if the other
+// side decides the result. Display conditions without a value hide the field, validators without a value pass.
+
+// ExpressionError is returned for expressions that cannot be parsed, do not type check or fail while being evaluated
+type ExpressionError struct {
+	Expression string
+	// Position is the column (counted in characters from 1) the error refers to
+	Position int
+	Message  string
+	// FieldId is the field or wizard page using the expression if the error was found by CheckExpressions
+	FieldId string
+	// expression is the expression the error was found in by CheckExpressions, schemas use it to report the path of the expression
+	expression *Expression
+}
+
+func (e *ExpressionError) Error() string {
+	message := "Invalid expression"
+	if e.FieldId != "" {
+		message += " of " + e.FieldId
+	}
+	return message + " at position " + strconv.Itoa(e.Position) + ": " + e.Message + " (expression: " + e.Expression + ")"
+}
+
+// Defining the tokens and the lexer
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdentifier
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// offset is the byte offset of the token in the source
+	offset int
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEnd:
+		return "end of expression"
+	case tokenString:
+		return "string " + t.text
+	default:
+		return "\"" + t.text + "\""
+	}
+}
+
+var expressionOperators = []string{"||", "&&", "==", "!=", "<=", ">=", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")", ","}
+
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	offset := 0
+	for offset < len(source) {
+		r, size := utf8.DecodeRuneInString(source[offset:])
+		switch {
+		case unicode.IsSpace(r):
+			offset += size
+		case r >= '0' && r <= '9':
+			end := offset
+			for end < len(source) && (source[end] >= '0' && source[end] <= '9' || source[end] == '.') {
+				end++
+			}
+			if _, err := strconv.ParseFloat(source[offset:end], 64); err != nil {
+				return nil, newExpressionError(source, offset, "invalid number "+source[offset:end])
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: source[offset:end], offset: offset})
+			offset = end
+		case r == '_' || unicode.IsLetter(r):
+			end := offset
+			for end < len(source) {
+				next, nextSize := utf8.DecodeRuneInString(source[end:])
+				if next != '_' && !unicode.IsLetter(next) && !unicode.IsDigit(next) {
+					break
+				}
+				end += nextSize
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, text: source[offset:end], offset: offset})
+			offset = end
+		case r == '"':
+			end := offset + 1
+			for end < len(source) && source[end] != '"' {
+				if source[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(source) {
+				return nil, newExpressionError(source, offset, "unterminated string")
+			}
+			if _, err := strconv.Unquote(source[offset : end+1]); err != nil {
+				return nil, newExpressionError(source, offset, "invalid string "+source[offset:end+1])
+			}
+			tokens = append(tokens, token{kind: tokenString, text: source[offset : end+1], offset: offset})
+			offset = end + 1
+		default:
+			operator := ""
+			for _, candidate := range expressionOperators {
+				if strings.HasPrefix(source[offset:], candidate) {
+					operator = candidate
+					break
+				}
+			}
+			if operator == "" {
+				return nil, newExpressionError(source, offset, "unexpected character "+strconv.QuoteRune(r))
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: operator, offset: offset})
+			offset += len(operator)
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, offset: len(source)}), nil
+}
+
+func newExpressionError(source string, offset int, message string) *ExpressionError {
+	return &ExpressionError{Expression: source, Position: utf8.RuneCountInString(source[:offset]) + 1, Message: message}
+}
+
+// Defining the syntax tree
+
+type exprType int
+
+const (
+	typeBool exprType = iota
+	typeNumber
+	typeString
+)
+
+func (t exprType) String() string {
+	switch t {
+	case typeBool:
+		return "bool"
+	case typeNumber:
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+// exprNode is a node of the syntax tree. check returns the type of the node, fields maps the field ids to their types.
+type exprNode interface {
+	check(fields map[string]exprType) (exprType, error)
+	eval(form *Form) (any, error)
+	offset() int
+}
+
+type literalNode struct {
+	value any
+	start int
+}
+
+type fieldNode struct {
+	id    string
+	start int
+}
+
+type unaryNode struct {
+	operator string
+	operand  exprNode
+	start    int
+}
+
+type binaryNode struct {
+	operator    string
+	left, right exprNode
+	start       int
+}
+
+type callNode struct {
+	name  string
+	args  []exprNode
+	start int
+}
+
+func (n *literalNode) offset() int { return n.start }
+func (n *fieldNode) offset() int   { return n.start }
+func (n *unaryNode) offset() int   { return n.start }
+func (n *binaryNode) offset() int  { return n.start }
+func (n *callNode) offset() int    { return n.start }
+
+// Defining the Pratt parser
+
+var binaryPrecedences = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+const unaryPrecedence = 7
+
+type parser struct {
+	source   string
+	tokens   []token
+	position int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.position]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.position]
+	if t.kind != tokenEnd {
+		p.position++
+	}
+	return t
+}
+
+func (p *parser) errorAt(t token, message string) *ExpressionError {
+	return newExpressionError(p.source, t.offset, message)
+}
+
+func (p *parser) expect(operator string) error {
+	if t := p.next(); t.kind != tokenOperator || t.text != operator {
+		return p.errorAt(t, "expected \""+operator+"\" but found "+t.describe())
+	}
+	return nil
+}
+
+func (p *parser) parseExpression(precedence int) (exprNode, error) {
+	left, err := p.parsePrefix()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		operatorPrecedence, ok := binaryPrecedences[t.text]
+		if t.kind != tokenOperator || !ok || operatorPrecedence <= precedence {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseExpression(operatorPrecedence)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{operator: t.text, left: left, right: right, start: t.offset}
+	}
+}
+
+func (p *parser) parsePrefix() (exprNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		value, _ := strconv.ParseFloat(t.text, 64)
+		return &literalNode{value: value, start: t.offset}, nil
+	case tokenString:
+		value, _ := strconv.Unquote(t.text)
+		return &literalNode{value: value, start: t.offset}, nil
+	case tokenIdentifier:
+		switch t.text {
+		case "true", "false":
+			return &literalNode{value: t.text == "true", start: t.offset}, nil
+		}
+		if p.peek().kind == tokenOperator && p.peek().text == "(" {
+			return p.parseCall(t)
+		}
+		return &fieldNode{id: t.text, start: t.offset}, nil
+	case tokenOperator:
+		switch t.text {
+		case "(":
+			node, err := p.parseExpression(0)
+			if err != nil {
+				return nil, err
+			}
+			return node, p.expect(")")
+		case "!", "-":
+			operand, err := p.parseExpression(unaryPrecedence)
+			if err != nil {
+				return nil, err
+			}
+			return &unaryNode{operator: t.text, operand: operand, start: t.offset}, nil
+		}
+	}
+	return nil, p.errorAt(t, "unexpected "+t.describe())
+}
+
+func (p *parser) parseCall(name token) (exprNode, error) {
+	p.next()
+	call := &callNode{name: name.text, start: name.offset}
+	if t := p.peek(); t.kind == tokenOperator && t.text == ")" {
+		p.next()
+		return call, nil
+	}
+	for {
+		arg, err := p.parseExpression(0)
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+		t := p.next()
+		if t.kind == tokenOperator && t.text == ")" {
+			return call, nil
+		}
+		if t.kind != tokenOperator || t.text != "," {
+			return nil, p.errorAt(t, "expected \",\" or \")\" but found "+t.describe())
+		}
+	}
+}
+
+// Defining the type checker
+
+// checkError is returned by the check methods, it is converted to an ExpressionError by Expression.Check
+type checkError struct {
+	start   int
+	message string
+}
+
+func (e *checkError) Error() string {
+	return e.message
+}
+
+func (n *literalNode) check(_ map[string]exprType) (exprType, error) {
+	return literalType(n.value), nil
+}
+
+func literalType(value any) exprType {
+	switch value.(type) {
+	case bool:
+		return typeBool
+	case float64:
+		return typeNumber
+	default:
+		return typeString
+	}
+}
+
+func (n *fieldNode) check(fields map[string]exprType) (exprType, error) {
+	fieldType, ok := fields[n.id]
+	if !ok {
+		return 0, &checkError{start: n.start, message: "unknown field " + n.id}
+	}
+	return fieldType, nil
+}
+
+func (n *unaryNode) check(fields map[string]exprType) (exprType, error) {
+	operandType, err := n.operand.check(fields)
+	if err != nil {
+		return 0, err
+	}
+	expected := typeNumber
+	if n.operator == "!" {
+		expected = typeBool
+	}
+	if operandType != expected {
+		return 0, &checkError{start: n.start, message: "operator " + n.operator + " expects a " + expected.String() + " but got a " + operandType.String()}
+	}
+	return expected, nil
+}
+
+func (n *binaryNode) check(fields map[string]exprType) (exprType, error) {
+	leftType, err := n.left.check(fields)
+	if err != nil {
+		return 0, err
+	}
+	rightType, err := n.right.check(fields)
+	if err != nil {
+		return 0, err
+	}
+	mismatch := &checkError{start: n.start, message: "operator " + n.operator + " cannot be used with a " + leftType.String() + " and a " + rightType.String()}
+	switch n.operator {
+	case "||", "&&":
+		if leftType != typeBool || rightType != typeBool {
+			return 0, mismatch
+		}
+		return typeBool, nil
+	case "==", "!=":
+		if leftType != rightType {
+			return 0, mismatch
+		}
+		return typeBool, nil
+	case "<", "<=", ">", ">=":
+		if leftType != rightType || leftType == typeBool {
+			return 0, mismatch
+		}
+		return typeBool, nil
+	case "+":
+		if leftType != rightType || leftType == typeBool {
+			return 0, mismatch
+		}
+		return leftType, nil
+	default:
+		if leftType != typeNumber || rightType != typeNumber {
+			return 0, mismatch
+		}
+		return typeNumber, nil
+	}
+}
+
+func (n *callNode) check(fields map[string]exprType) (exprType, error) {
+	if len(n.args) != 1 {
+		return 0, &checkError{start: n.start, message: "function " + n.name + " expects 1 argument but got " + strconv.Itoa(len(n.args))}
+	}
+	switch n.name {
+	case "len":
+		argType, err := n.args[0].check(fields)
+		if err != nil {
+			return 0, err
+		}
+		if argType != typeString {
+			return 0, &checkError{start: n.args[0].offset(), message: "function len expects a string but got a " + argType.String()}
+		}
+		return typeNumber, nil
+	case "visible", "valid":
+		literal, ok := n.args[0].(*literalNode)
+		id, isString := literal.stringValue()
+		if !ok || !isString {
+			return 0, &checkError{start: n.args[0].offset(), message: "function " + n.name + " expects the id of a field as string"}
+		}
+		if _, ok := fields[id]; !ok {
+			return 0, &checkError{start: n.args[0].offset(), message: "unknown field " + id}
+		}
+		return typeBool, nil
+	default:
+		return 0, &checkError{start: n.start, message: "unknown function " + n.name}
+	}
+}
+
+func (n *literalNode) stringValue() (string, bool) {
+	if n == nil {
+		return "", false
+	}
+	value, ok := n.value.(string)
+	return value, ok
+}
+
+// Defining the evaluator
+
+func (n *literalNode) eval(_ *Form) (any, error) {
+	return n.value, nil
+}
+
+func (n *fieldNode) eval(form *Form) (any, error) {
+	field := form.lookupField(n.id)
+	fieldType, ok := expressionFieldType(field)
+	if !ok {
+		return nil, &checkError{start: n.start, message: "unknown field " + n.id}
+	}
+	if fieldType != typeNumber {
+		return field.GetValue(), nil
+	}
+	if strings.TrimSpace(field.GetValue()) == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseFloat(field.GetValue(), 64)
+	if err != nil {
+		return nil, &checkError{start: n.start, message: "value of " + n.id + " is not a number"}
+	}
+	return value, nil
+}
+
+func (n *unaryNode) eval(form *Form) (any, error) {
+	operand, err := n.operand.eval(form)
+	if err != nil || operand == nil {
+		return nil, err
+	}
+	switch value := operand.(type) {
+	case bool:
+		if n.operator == "!" {
+			return !value, nil
+		}
+	case float64:
+		if n.operator == "-" {
+			return -value, nil
+		}
+	}
+	return nil, &checkError{start: n.start, message: "operator " + n.operator + " cannot be used with a " + literalType(operand).String()}
+}
+
+func (n *binaryNode) eval(form *Form) (any, error) {
+	left, err := n.left.eval(form)
+	if err != nil {
+		return nil, err
+	}
+	// || and && only evaluate the right side if needed
+	if leftBool, ok := left.(bool); ok && (n.operator == "||" && leftBool || n.operator == "&&" && !leftBool) {
+		return leftBool, nil
+	}
+	right, err := n.right.eval(form)
+	if err != nil {
+		return nil, err
+	}
+	if left == nil || right == nil {
+		return n.evalWithoutValue(left, right), nil
+	}
+	if n.operator == "==" || n.operator == "!=" {
+		if literalType(left) != literalType(right) {
+			return nil, n.mismatch(left, right)
+		}
+		return (left == right) == (n.operator == "=="), nil
+	}
+	switch left := left.(type) {
+	case bool:
+		if right, ok := right.(bool); ok && (n.operator == "||" || n.operator == "&&") {
+			return right, nil
+		}
+	case float64:
+		if right, ok := right.(float64); ok {
+			return n.evalNumbers(left, right)
+		}
+	case string:
+		if right, ok := right.(string); ok {
+			return n.evalStrings(left, right)
+		}
+	}
+	return nil, n.mismatch(left, right)
+}
+
+// evalWithoutValue evaluates the operator if a side has no value, || and && still have a value if the other side decides the result
+func (n *binaryNode) evalWithoutValue(left any, right any) any {
+	switch n.operator {
+	case "||":
+		if left == true || right == true {
+			return true
+		}
+	case "&&":
+		if left == false || right == false {
+			return false
+		}
+	}
+	return nil
+}
+
+func (n *binaryNode) mismatch(left any, right any) error {
+	return &checkError{start: n.start, message: "operator " + n.operator + " cannot be used with a " + literalType(left).String() + " and a " + literalType(right).String()}
+}
+
+func (n *binaryNode) evalNumbers(left float64, right float64) (any, error) {
+	switch n.operator {
+	case "<":
+		return left < right, nil
+	case "<=":
+		return left <= right, nil
+	case ">":
+		return left > right, nil
+	case ">=":
+		return left >= right, nil
+	case "+":
+		return left + right, nil
+	case "-":
+		return left - right, nil
+	case "*":
+		return left * right, nil
+	case "/", "%":
+		if right == 0 {
+			return nil, &checkError{start: n.start, message: "division by zero"}
+		}
+		if n.operator == "/" {
+			return left / right, nil
+		}
+		return math.Mod(left, right), nil
+	}
+	return nil, n.mismatch(left, right)
+}
+
+func (n *binaryNode) evalStrings(left string, right string) (any, error) {
+	switch n.operator {
+	case "<":
+		return left < right, nil
+	case "<=":
+		return left <= right, nil
+	case ">":
+		return left > right, nil
+	case ">=":
+		return left >= right, nil
+	case "+":
+		return left + right, nil
+	}
+	return nil, n.mismatch(left, right)
+}
+
+func (n *callNode) eval(form *Form) (any, error) {
+	if len(n.args) != 1 {
+		return nil, &checkError{start: n.start, message: "function " + n.name + " expects 1 argument but got " + strconv.Itoa(len(n.args))}
+	}
+	arg, err := n.args[0].eval(form)
+	if err != nil {
+		return nil, err
+	}
+	switch n.name {
+	case "len":
+		if value, ok := arg.(string); ok {
+			return float64(utf8.RuneCountInString(value)), nil
+		}
+		return nil, &checkError{start: n.args[0].offset(), message: "function len expects a string but got a " + literalType(arg).String()}
+	case "visible", "valid":
+		id, _ := arg.(string)
+		field := form.lookupField(id)
+		if field == nil {
+			return nil, &checkError{start: n.args[0].offset(), message: "unknown field " + id}
+		}
+		if n.name == "visible" {
+			return isDisplayed(form, field), nil
+		}
+		return field.IsValid(), nil
+	}
+	return nil, &checkError{start: n.start, message: "unknown function " + n.name}
+}
+
+// expressionFieldType returns the type of the values of a field in expressions and false for fields without a value
+func expressionFieldType(field Field) (exprType, bool) {
+	switch field.(type) {
+	case *NumberField:
+		return typeNumber, true
+	case *TextField, *MultipleChoiceField, *ComputedField:
+		return typeString, true
+	default:
+		return 0, false
+	}
+}
+
+// Defining the Expression Type
+
+// Expression is a parsed expression that can be evaluated against the values of a form
+type Expression struct {
+	source string
+	root   exprNode
+}
+
+// ParseExpression parses an expression, syntax errors are returned as *ExpressionError with the position of the error
+func ParseExpression(source string) (*Expression, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{source: source, tokens: tokens}
+	root, err := p.parseExpression(0)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEnd {
+		return nil, p.errorAt(t, "unexpected "+t.describe())
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+func (e *Expression) String() string {
+	return e.source
+}
+
+// References returns the ids of the fields the expression refers to
+func (e *Expression) References() []string {
+	var ids []string
+	var collect func(node exprNode)
+	collect = func(node exprNode) {
+		switch node := node.(type) {
+		case *fieldNode:
+			ids = append(ids, node.id)
+		case *unaryNode:
+			collect(node.operand)
+		case *binaryNode:
+			collect(node.left)
+			collect(node.right)
+		case *callNode:
+			for _, arg := range node.args {
+				if literal, ok := arg.(*literalNode); ok && node.name != "len" {
+					if id, ok := literal.stringValue(); ok {
+						ids = append(ids, id)
+					}
+				}
+				collect(arg)
+			}
+		}
+	}
+	collect(e.root)
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// exprCall is a call of visible or valid with the id of a field
+type exprCall struct {
+	name  string
+	id    string
+	start int
+}
+
+// calls returns the calls of visible and valid in the expression
+func (e *Expression) calls() []exprCall {
+	var calls []exprCall
+	var collect func(node exprNode)
+	collect = func(node exprNode) {
+		switch node := node.(type) {
+		case *unaryNode:
+			collect(node.operand)
+		case *binaryNode:
+			collect(node.left)
+			collect(node.right)
+		case *callNode:
+			for _, arg := range node.args {
+				if literal, ok := arg.(*literalNode); ok && (node.name == "visible" || node.name == "valid") {
+					if id, ok := literal.stringValue(); ok {
+						calls = append(calls, exprCall{name: node.name, id: id, start: node.start})
+					}
+				}
+				collect(arg)
+			}
+		}
+	}
+	collect(e.root)
+	return calls
+}
+
+// Check type checks the expression against the fields of the form and makes sure it evaluates to a bool
+func (e *Expression) Check(form *Form) error {
+	fields := make(map[string]exprType)
+	for _, field := range form.GetAllFields() {
+		if fieldType, ok := expressionFieldType(field); ok {
+			fields[field.GetId()] = fieldType
+		}
+	}
+	resultType, err := e.root.check(fields)
+	if err != nil {
+		return e.wrapError(err)
+	}
+	if resultType != typeBool {
+		return newExpressionError(e.source, e.root.offset(), "expression has to be a bool but is a "+resultType.String())
+	}
+	return nil
+}
+
+// Evaluate evaluates the expression with the current values of the form and returns a bool, float64 or string.
+// It returns nil if the value is unknown because an empty number field is used.
+func (e *Expression) Evaluate(form *Form) (any, error) {
+	value, err := e.root.eval(form)
+	if err != nil {
+		return nil, e.wrapError(err)
+	}
+	return value, nil
+}
+
+func (e *Expression) wrapError(err error) error {
+	if checkErr, ok := err.(*checkError); ok {
+		return newExpressionError(e.source, checkErr.start, checkErr.message)
+	}
+	return err
+}
+
+// evaluateBool evaluates the expression and returns an error if it is not a bool, known is false if the expression has no value
+func (e *Expression) evaluateBool(form *Form) (result bool, known bool, err error) {
+	value, err := e.Evaluate(form)
+	if err != nil || value == nil {
+		return false, false, err
+	}
+	result, ok := value.(bool)
+	if !ok {
+		return false, false, newExpressionError(e.source, e.root.offset(), "expression has to be a bool but is a "+literalType(value).String())
+	}
+	return result, true, nil
+}
+
+// Defining the expression Display Condition and Validator
+
+// ExpressionDisplayCondition displays the field if the expression is true, it is not displayed if the expression cannot be evaluated
+// or has no value
+type ExpressionDisplayCondition struct {
+	Expression *Expression
+}
+
+func (d *ExpressionDisplayCondition) DisplayCondition(field any) bool {
+	result, known, err := d.Expression.evaluateBool(getFieldBase(field).form)
+	return err == nil && known && result
+}
+
+func (d *ExpressionDisplayCondition) expressions() []*Expression {
+	return []*Expression{d.Expression}
+}
+
+// ExpressionValidator fails with Message if the expression is false and with the evaluation error if it cannot be evaluated.
+// It passes if the expression has no value because a number field it uses is empty, Required decides if the field may be empty.
+type ExpressionValidator struct {
+	Expression *Expression
+	Message    string
+}
+
+func (v *ExpressionValidator) GetDependencies() []string {
+	return v.Expression.References()
+}
+
+func (v *ExpressionValidator) Validate(field any) bool {
+	base := getFieldBase(field)
+	result, known, err := v.Expression.evaluateBool(base.form)
+	if err != nil {
+		base.setError(&CustomError{Code: ErrorCodeExpression, Message: err.Error()})
+		return false
+	}
+	if !known {
+		return true
+	}
+	if !result {
+		message := v.Message
+		if message == "" {
+			message = "Field does not satisfy " + v.Expression.String()
+		}
+		base.setError(&CustomError{Code: ErrorCodeExpression, Message: message})
+	}
+	return result
+}
+
+func (v *ExpressionValidator) expressions() []*Expression {
+	return []*Expression{v.Expression}
+}
+
+// expressionHolder is implemented by display conditions and validators containing expressions
+type expressionHolder interface {
+	expressions() []*Expression
+}
+
+func (d *OrDisplayCondition) expressions() []*Expression {
+	return conditionExpressions(d.Conditions...)
+}
+
+func (d *AndDisplayCondition) expressions() []*Expression {
+	return conditionExpressions(d.Conditions...)
+}
+
+// conditionExpressions returns the expressions of the display conditions including the ones nested in combinators
+func conditionExpressions(conditions ...DisplayCondition) []*Expression {
+	var expressions []*Expression
+	for _, condition := range conditions {
+		if holder, ok := condition.(expressionHolder); ok {
+			expressions = append(expressions, holder.expressions()...)
+		}
+	}
+	return expressions
+}
+
+// validatorExpressions returns the expressions of the validators including the ones nested in combinators
+func validatorExpressions(validators ...Validator) []*Expression {
+	var expressions []*Expression
+	for _, validator := range validators {
+		if holder, ok := validator.(expressionHolder); ok {
+			expressions = append(expressions, holder.expressions()...)
+		}
+	}
+	return expressions
+}
+
+// validityExpressions returns the expressions evaluated when the field is validated (see FieldBaseType.validate)
+func validityExpressions(base *FieldBaseType) []*Expression {
+	expressions := conditionExpressions(base.DisplayConditions...)
+	expressions = append(expressions, conditionExpressions(base.DisableConditions...)...)
+	expressions = append(expressions, conditionExpressions(base.RequiredIf...)...)
+	return append(expressions, validatorExpressions(base.Validators...)...)
+}
+
+// CheckExpressions type checks the expressions of all display conditions and validators of the fields and wizard pages and makes sure
+// that visible and valid do not depend on themselves (e.g. two fields displayed only if the other one is visible).
+// The returned *ExpressionError contains the id of the field or page using the invalid expression.
+func (f *Form) CheckExpressions() error {
+	for _, page := range f.pages {
+		if err := checkExpressions(f, conditionExpressions(page.DisplayConditions...)); err != nil {
+			return withFieldId(err, page.Id)
+		}
+	}
+	for _, field := range f.GetAllFields() {
+		base := getFieldBase(field)
+		if base == nil {
+			continue
+		}
+		if err := checkExpressions(f, validityExpressions(base)); err != nil {
+			return withFieldId(err, base.Id)
+		}
+	}
+	return f.checkExpressionCycles()
+}
+
+func checkExpressions(form *Form, expressions []*Expression) error {
+	for _, expression := range expressions {
+		if err := expression.Check(form); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expressionEdge leads from visible or valid of a field to the visible or valid it evaluates. Calls in expressions keep the expression,
+// the position of the call and the field or page using it. Edges without an expression lead from visible of a field to visible of the
+// groups and the page containing it.
+type expressionEdge struct {
+	to         string
+	ownerId    string
+	expression *Expression
+	start      int
+}
+
+// checkExpressionCycles returns an *ExpressionError if evaluating visible or valid of a field evaluates it again, which would never end
+func (f *Form) checkExpressionCycles() error {
+	graph := make(map[string][]expressionEdge)
+	var nodes []string
+	addCalls := func(from string, ownerId string, expressions []*Expression) {
+		for _, expression := range expressions {
+			for _, call := range expression.calls() {
+				graph[from] = append(graph[from], expressionEdge{to: call.name + "(" + call.id + ")", ownerId: ownerId, expression: expression, start: call.start})
+			}
+		}
+	}
+	for _, page := range f.pages {
+		node := "visible(" + page.Id + ")"
+		nodes = append(nodes, node)
+		addCalls(node, page.Id, conditionExpressions(page.DisplayConditions...))
+	}
+	for _, field := range f.GetAllFields() {
+		base := getFieldBase(field)
+		if base == nil {
+			continue
+		}
+		visible, valid := "visible("+base.Id+")", "valid("+base.Id+")"
+		nodes = append(nodes, visible, valid)
+		addCalls(visible, base.Id, conditionExpressions(base.DisplayConditions...))
+		for _, group := range f.getParentGroups(base.Id) {
+			graph[visible] = append(graph[visible], expressionEdge{to: "visible(" + group.Id + ")"})
+		}
+		for _, page := range f.pages {
+			if slices.Contains(flattenFields(page.Fields), field) {
+				graph[visible] = append(graph[visible], expressionEdge{to: "visible(" + page.Id + ")"})
+			}
+		}
+		addCalls(valid, base.Id, validityExpressions(base))
+	}
+	visiting := make(map[string]bool)
+	done := make(map[string]bool)
+	// path contains the nodes being visited, edges the edges between them
+	var path []string
+	var edges []expressionEdge
+	var visit func(node string) error
+	visit = func(node string) error {
+		visiting[node] = true
+		path = append(path, node)
+		for _, edge := range graph[node] {
+			if visiting[edge.to] {
+				start := slices.Index(path, edge.to)
+				return expressionCycleError(append(slices.Clone(path[start:]), edge.to), append(slices.Clone(edges[start:]), edge))
+			}
+			if done[edge.to] {
+				continue
+			}
+			edges = append(edges, edge)
+			if err := visit(edge.to); err != nil {
+				return err
+			}
+			edges = edges[:len(edges)-1]
+		}
+		path = path[:len(path)-1]
+		visiting[node] = false
+		done[node] = true
+		return nil
+	}
+	for _, node := range nodes {
+		if done[node] {
+			continue
+		}
+		if err := visit(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expressionCycleError reports a cycle at the first call of an expression in it, every cycle contains one because groups and pages
+// do not contain each other
+func expressionCycleError(cycle []string, edges []expressionEdge) error {
+	index := slices.IndexFunc(edges, func(edge expressionEdge) bool { return edge.expression != nil })
+	edge := edges[index]
+	err := newExpressionError(edge.expression.source, edge.start, "expressions depend on each other ("+strings.Join(cycle, " -> ")+")")
+	err.FieldId = edge.ownerId
+	err.expression = edge.expression
+	return err
+}
+
+func withFieldId(err error, id string) error {
+	if expressionErr, ok := err.(*ExpressionError); ok && expressionErr.FieldId == "" {
+		withId := *expressionErr
+		withId.FieldId = id
+		return &withId
+	}
+	return err
+}
+
+// Defining the expression builder functions
+
+// NewExpressionDisplayCondition parses the expression and creates a display condition from it
+func NewExpressionDisplayCondition(expression string) (*ExpressionDisplayCondition, error) {
+	parsed, err := ParseExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	return &ExpressionDisplayCondition{Expression: parsed}, nil
+}
+
+// MustExpressionDisplayCondition is like NewExpressionDisplayCondition but panics if the expression cannot be parsed
+func MustExpressionDisplayCondition(expression string) *ExpressionDisplayCondition {
+	condition, err := NewExpressionDisplayCondition(expression)
+	if err != nil {
+		panic(err)
+	}
+	return condition
+}
+
+// NewExpressionValidator parses the expression and creates a validator from it, message is the error if the expression is false
+func NewExpressionValidator(expression string, message string) (*ExpressionValidator, error) {
+	parsed, err := ParseExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	return &ExpressionValidator{Expression: parsed, Message: message}, nil
+}
+
+// MustExpressionValidator is like NewExpressionValidator but panics if the expression cannot be parsed
+func MustExpressionValidator(expression string, message string) *ExpressionValidator {
+	validator, err := NewExpressionValidator(expression, message)
+	if err != nil {
+		panic(err)
+	}
+	return validator
+}
+
+// NewCheckedForm creates a new form like NewForm and type checks its expressions (see CheckExpressions).
+// Unlike NewForm it returns an error if computed fields depend on each other in a cycle.
+func NewCheckedForm(fields ...Field) (*Form, error) {
+	if _, err := computedFieldOrder(fields); err != nil {
+		return nil, err
+	}
+	form := NewForm(fields...)
+	if err := form.CheckExpressions(); err != nil {
+		return nil, err
+	}
+	return form, nil
+}
@@ -0,0 +1,243 @@
+package go_forms
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newExpressionForm() *Form {
+	return NewForm(
+		NewTextField("name", nil, nil, "", "Name", "Steve"),
+		NewNumberField("ram", nil, nil, "", "RAM", 4096),
+	)
+}
+
+func TestExpressionPrecedence(t *testing.T) {
+	tests := []struct {
+		source string
+		value  any
+	}{
+		{"1 + 2 * 3", 7.0},
+		{"(1 + 2) * 3", 9.0},
+		{"10 - 4 - 3", 3.0},
+		{"7 % 4 + 1", 4.0},
+		{"-2 * 3", -6.0},
+		{"ram / 2 / 2", 1024.0},
+		{"true || false && false", true},
+		{"!false && false", false},
+		{"1 < 2 == 2 < 3", true},
+		{`"a" + "b" == "ab"`, true},
+		{"len(name) * 2", 10.0},
+	}
+	form := newExpressionForm()
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			expression, err := ParseExpression(test.source)
+			if err != nil {
+				t.Fatalf("ParseExpression returned %v", err)
+			}
+			if value, err := expression.Evaluate(form); err != nil || value != test.value {
+				t.Errorf("Evaluate returned %v (error: %v), expected %v", value, err, test.value)
+			}
+		})
+	}
+}
+
+func TestExpressionSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		source   string
+		position int
+		message  string
+	}{
+		{"ram >= ", 8, "unexpected end of expression"},
+		{`name == "abc`, 9, "unterminated string"},
+		{"(1 + 2", 7, `expected ")"`},
+		{"1 $ 2", 3, "unexpected character"},
+		{"len(name 1)", 10, `expected "," or ")"`},
+		{"1 2", 3, `unexpected "2"`},
+		{`"ä" == x $`, 10, "unexpected character"},
+	}
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			_, err := ParseExpression(test.source)
+			var expressionErr *ExpressionError
+			if !errors.As(err, &expressionErr) {
+				t.Fatalf("ParseExpression returned %v, expected an *ExpressionError", err)
+			}
+			if expressionErr.Position != test.position || !strings.Contains(expressionErr.Message, test.message) {
+				t.Errorf("error is %q at position %d, expected %q at position %d", expressionErr.Message, expressionErr.Position, test.message, test.position)
+			}
+		})
+	}
+}
+
+func TestExpressionTypeErrors(t *testing.T) {
+	tests := []struct {
+		source   string
+		position int
+		message  string
+	}{
+		{"name == 1", 6, "operator == cannot be used with a string and a number"},
+		{`ram + "MB" == ""`, 5, "operator + cannot be used with a number and a string"},
+		{"len(ram) > 1", 5, "function len expects a string but got a number"},
+		{"!ram", 1, "operator ! expects a bool but got a number"},
+		{"ram", 1, "expression has to be a bool but is a number"},
+		{"visible(name)", 9, "expects the id of a field as string"},
+		{"motd == name", 1, "unknown field motd"},
+		{`valid("motd")`, 7, "unknown field motd"},
+		{"upper(name) == name", 1, "unknown function upper"},
+	}
+	form := newExpressionForm()
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			expression, err := ParseExpression(test.source)
+			if err != nil {
+				t.Fatalf("ParseExpression returned %v", err)
+			}
+			err = expression.Check(form)
+			var expressionErr *ExpressionError
+			if !errors.As(err, &expressionErr) {
+				t.Fatalf("Check returned %v, expected an *ExpressionError", err)
+			}
+			if expressionErr.Position != test.position || !strings.Contains(expressionErr.Message, test.message) {
+				t.Errorf("error is %q at position %d, expected %q at position %d", expressionErr.Message, expressionErr.Position, test.message, test.position)
+			}
+		})
+	}
+}
+
+func TestExpressionShortCircuit(t *testing.T) {
+	tests := []struct {
+		source string
+		value  any
+		err    string
+	}{
+		{"true || 1 / 0 == 1", true, ""},
+		{"false && 1 / 0 == 1", false, ""},
+		{"false || 1 / 0 == 1", nil, "division by zero"},
+		{"true && 1 % 0 == 1", nil, "division by zero"},
+	}
+	form := newExpressionForm()
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			value, err := MustExpressionValidator(test.source, "").Expression.Evaluate(form)
+			switch {
+			case test.err != "":
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("Evaluate returned %v, expected the error %q", err, test.err)
+				}
+			case err != nil || value != test.value:
+				t.Errorf("Evaluate returned %v (error: %v), expected %v", value, err, test.value)
+			}
+		})
+	}
+}
+
+func TestExpressionEmptyNumberHasNoValue(t *testing.T) {
+	form := newExpressionForm()
+	form.lookupField("ram").SetValue("")
+	tests := []struct {
+		source string
+		value  any
+	}{
+		{"ram >= 0 || true", true},
+		{"ram >= 0 && false", false},
+		{"ram >= 0", nil},
+		{"ram == 0", nil},
+		{"!(ram > 0)", nil},
+		{"-ram + 1 > 0 || false", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			expression, err := ParseExpression(test.source)
+			if err != nil {
+				t.Fatalf("ParseExpression returned %v", err)
+			}
+			if value, err := expression.Evaluate(form); err != nil || value != test.value {
+				t.Errorf("Evaluate returned %v (error: %v), expected %v", value, err, test.value)
+			}
+		})
+	}
+
+	ram := NewNumberField("ram", nil, []Validator{MustExpressionValidator("ram >= 1024", "Not enough RAM")}, "", "RAM", 0)
+	plugins := NewTextField("plugins", []DisplayCondition{MustExpressionDisplayCondition("ram >= 2048")}, nil, "", "Plugins", "")
+	form = NewForm(ram, plugins)
+	ram.SetValue("")
+	if !ram.IsValid() {
+		t.Errorf("empty optional field is invalid (%v)", ram.GetError())
+	}
+	if plugins.ShouldDisplay() {
+		t.Errorf("plugins are displayed although ram is empty")
+	}
+	ram.SetValue("512")
+	if ram.IsValid() {
+		t.Errorf("ram of 512 is valid")
+	}
+}
+
+func TestExpressionCycles(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  func() []Field
+		fieldId string
+		cycle   string
+	}{
+		{
+			name: "visible",
+			fields: func() []Field {
+				return []Field{
+					NewTextField("a", []DisplayCondition{MustExpressionDisplayCondition(`visible("b")`)}, nil, "", "A", ""),
+					NewTextField("b", []DisplayCondition{MustExpressionDisplayCondition(`visible("a")`)}, nil, "", "B", ""),
+				}
+			},
+			fieldId: "a",
+			cycle:   "visible(a) -> visible(b) -> visible(a)",
+		},
+		{
+			name: "valid",
+			fields: func() []Field {
+				return []Field{
+					NewTextField("a", nil, []Validator{MustExpressionValidator(`valid("b")`, "")}, "", "A", ""),
+					NewTextField("b", []DisplayCondition{MustExpressionDisplayCondition(`!valid("a")`)}, nil, "", "B", ""),
+				}
+			},
+			fieldId: "a",
+			cycle:   "valid(a) -> valid(b) -> valid(a)",
+		},
+		{
+			name: "group",
+			fields: func() []Field {
+				return []Field{
+					NewFieldGroup("g", []DisplayCondition{MustExpressionDisplayCondition(`visible("x")`)}, nil, "Group",
+						NewTextField("x", nil, nil, "", "X", ""),
+					),
+				}
+			},
+			fieldId: "g",
+			cycle:   "visible(g) -> visible(x) -> visible(g)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewCheckedForm(test.fields()...)
+			var expressionErr *ExpressionError
+			if !errors.As(err, &expressionErr) {
+				t.Fatalf("NewCheckedForm returned %v, expected an *ExpressionError", err)
+			}
+			if expressionErr.FieldId != test.fieldId || !strings.Contains(expressionErr.Message, test.cycle) {
+				t.Errorf("error is %v, expected the cycle %s of %s", err, test.cycle, test.fieldId)
+			}
+		})
+	}
+
+	// Fields comparing their values with each other do not evaluate each other
+	_, err := NewCheckedForm(
+		NewNumberField("min", []DisplayCondition{MustExpressionDisplayCondition(`visible("max") || min < max`)}, []Validator{MustExpressionValidator("min < max", "")}, "", "Min", 0),
+		NewNumberField("max", nil, []Validator{MustExpressionValidator(`max > min && valid("other")`, "")}, "", "Max", 1),
+		NewTextField("other", nil, nil, "", "Other", ""),
+	)
+	if err != nil {
+		t.Errorf("NewCheckedForm returned %v for fields without a cycle", err)
+	}
+}
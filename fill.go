@@ -0,0 +1,224 @@
+package go_forms
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ValueSource supplies the values of fields when a form is filled without a UI (see Fill)
+type ValueSource interface {
+	// Lookup returns the value for the field with the given id and false if the source has no value for it
+	Lookup(id string) (string, bool)
+}
+
+// MapSource supplies the values of a map from field ids to values
+type MapSource map[string]string
+
+func (s MapSource) Lookup(id string) (string, bool) {
+	value, ok := s[id]
+	return value, ok
+}
+
+// EnvSource supplies values from environment variables named like EnvVariableName(Prefix, id)
+type EnvSource struct {
+	Prefix string
+	// LookupEnv defaults to os.LookupEnv
+	LookupEnv func(name string) (string, bool)
+}
+
+func (s *EnvSource) Lookup(id string) (string, bool) {
+	lookupEnv := s.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+	return lookupEnv(EnvVariableName(s.Prefix, id))
+}
+
+var invalidEnvCharacters = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// EnvVariableName returns the name of the environment variable for a field, e.g. "SERVER_MAX_RAM" for the prefix "SERVER_" and the id "max-ram"
+func EnvVariableName(prefix string, id string) string {
+	name := invalidEnvCharacters.ReplaceAllString(strings.ToUpper(prefix+id), "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// ParseAnswers decodes an answer file, which is a JSON object from field ids to values like the JSON output of the go-forms command.
+// Numbers and booleans are converted to strings, null values are ignored.
+func ParseAnswers(data []byte) (MapSource, error) {
+	var answers map[string]json.RawMessage
+	if err := json.Unmarshal(data, &answers); err != nil {
+		return nil, err
+	}
+	source := make(MapSource, len(answers))
+	for id, raw := range answers {
+		var value any
+		decoder := json.NewDecoder(strings.NewReader(string(raw)))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		switch value := value.(type) {
+		case nil:
+			continue
+		case string:
+			source[id] = value
+		case json.Number:
+			source[id] = value.String()
+		case bool:
+			source[id] = "false"
+			if value {
+				source[id] = "true"
+			}
+		default:
+			return nil, &CustomError{Message: "Answer is not a string, number or boolean (field: " + id + ")"}
+		}
+	}
+	return source, nil
+}
+
+// LoadAnswerFile reads an answer file (see ParseAnswers)
+func LoadAnswerFile(path string) (MapSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAnswers(data)
+}
+
+// ParseArgs reads values from command-line arguments in the forms "--id=value", "--id value" and "id=value"
+func ParseArgs(args []string) (MapSource, error) {
+	source := make(MapSource)
+	for index := 0; index < len(args); index++ {
+		arg := args[index]
+		if name, ok := strings.CutPrefix(arg, "--"); ok {
+			if id, value, ok := strings.Cut(name, "="); ok {
+				source[id] = value
+				continue
+			}
+			if index+1 >= len(args) {
+				return nil, &CustomError{Message: "Missing value for argument " + arg}
+			}
+			index++
+			source[name] = args[index]
+			continue
+		}
+		id, value, ok := strings.Cut(arg, "=")
+		if !ok || id == "" {
+			return nil, &CustomError{Message: "Argument is not of the form id=value (argument: " + arg + ")"}
+		}
+		source[id] = value
+	}
+	return source, nil
+}
+
+// FieldError is the error of a single field reported by Fill
+type FieldError struct {
+	FieldId string
+	// Missing is true if no source had a value for the field and the field is empty
+	Missing bool
+	Err     error
+}
+
+func (e *FieldError) Error() string {
+	if e.Missing {
+		return e.FieldId + " is missing (" + e.Err.Error() + ")"
+	}
+	return e.FieldId + " is not valid (" + e.Err.Error() + ")"
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FillError is returned by Fill with all missing and invalid fields
+type FillError struct {
+	Errors []*FieldError
+}
+
+func (e *FillError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldError := range e.Errors {
+		messages = append(messages, fieldError.Error())
+	}
+	return "Form cannot be filled: " + strings.Join(messages, "; ")
+}
+
+// MissingFields returns the ids of the fields no source had a value for
+func (e *FillError) MissingFields() []string {
+	var ids []string
+	for _, fieldError := range e.Errors {
+		if fieldError.Missing {
+			ids = append(ids, fieldError.FieldId)
+		}
+	}
+	return ids
+}
+
+// Prefill sets the values of all fields the sources have a value for and returns their ids.
+// The sources are searched in order, the first source with a value wins. Messages, groups and read-only fields are skipped.
+func (f *Form) Prefill(sources ...ValueSource) []string {
+	var provided []string
+	for _, field := range f.GetAllFields() {
+		switch field.(type) {
+		case *Message, *FieldGroup:
+			continue
+		}
+		if base := getFieldBase(field); base != nil && base.IsReadOnly() {
+			continue
+		}
+		for _, source := range sources {
+			if value, ok := source.Lookup(field.GetId()); ok {
+				field.SetValue(value)
+				provided = append(provided, field.GetId())
+				break
+			}
+		}
+	}
+	return provided
+}
+
+// Fill fills the form without a UI: it sets the values from the sources (see Prefill) and validates the form like a submit.
+// Fields without a value in the sources keep their current value and hidden fields are not validated.
+// If fields are invalid, a *FillError listing all of them is returned, otherwise the values of the form.
+func (f *Form) Fill(ctx context.Context, sources ...ValueSource) (map[string]string, error) {
+	provided := f.Prefill(sources...)
+	if err := f.waitAsyncValidation(ctx); err != nil {
+		return nil, err
+	}
+	var errors []*FieldError
+	for _, field := range f.getFieldsToValidate() {
+		errors = append(errors, collectFieldErrors(field, provided)...)
+	}
+	if len(errors) > 0 {
+		return nil, &FillError{Errors: errors}
+	}
+	return f.GetFieldValues(), nil
+}
+
+// collectFieldErrors validates a field and the fields nested in it and returns the errors of all invalid ones
+func collectFieldErrors(field Field, provided []string) []*FieldError {
+	if !field.ShouldDisplay() {
+		return nil
+	}
+	var errors []*FieldError
+	if group, ok := field.(*FieldGroup); ok {
+		for _, child := range group.Fields {
+			errors = append(errors, collectFieldErrors(child, provided)...)
+		}
+		if len(errors) > 0 {
+			return errors
+		}
+	}
+	if !field.IsValid() {
+		missing := !slices.Contains(provided, field.GetId()) && field.GetValue() == ""
+		errors = append(errors, &FieldError{FieldId: field.GetId(), Missing: missing, Err: field.GetError()})
+	}
+	return errors
+}
@@ -0,0 +1,130 @@
+package go_forms
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func newFillForm() *Form {
+	name := NewTextField("name", nil, []Validator{&MaxLengthValidator{MaxLength: 8}}, "", "Name", "")
+	name.Required = true
+	return NewForm(
+		name,
+		NewMultipleChoiceField("software", nil, nil, "", "Software", map[string]Option{"paper": {Label: "Paper"}, "vanilla": {Label: "Vanilla"}}, "paper"),
+		NewNumberField("ram", []DisplayCondition{&HasValueDisplayCondition{FieldId: "software", Value: "paper"}}, []Validator{&MinValidator{Min: 1024}}, "", "RAM", 2048),
+		NewFieldGroup("network", nil, nil, "Network", NewNumberField("port", nil, []Validator{&PortValidator{}}, "", "Port", 25565)),
+	)
+}
+
+func TestFill(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []ValueSource
+		values  map[string]string
+		missing []string
+		invalid []string
+	}{
+		{
+			name:    "valid",
+			sources: []ValueSource{MapSource{"name": "lobby", "port": "25566"}},
+			values:  map[string]string{"name": "lobby", "software": "paper", "ram": "2048", "network": `{"port":"25566"}`},
+		},
+		{
+			name:    "first source wins",
+			sources: []ValueSource{MapSource{"name": "lobby"}, MapSource{"name": "survival", "ram": "4096"}},
+			values:  map[string]string{"name": "lobby", "software": "paper", "ram": "4096", "network": `{"port":"25565"}`},
+		},
+		{
+			name: "environment",
+			sources: []ValueSource{&EnvSource{Prefix: "SERVER_", LookupEnv: func(name string) (string, bool) {
+				value, ok := map[string]string{"SERVER_NAME": "lobby", "SERVER_SOFTWARE": "vanilla"}[name]
+				return value, ok
+			}}},
+			values: map[string]string{"name": "lobby", "software": "vanilla", "ram": "2048", "network": `{"port":"25565"}`},
+		},
+		{
+			name:    "missing and invalid fields",
+			sources: []ValueSource{MapSource{"ram": "512", "port": "70000"}},
+			missing: []string{"name"},
+			invalid: []string{"name", "ram", "port"},
+		},
+		{
+			name:    "hidden fields are not validated",
+			sources: []ValueSource{MapSource{"name": "lobby", "software": "vanilla", "ram": "512"}},
+			values:  map[string]string{"name": "lobby", "software": "vanilla", "ram": "512", "network": `{"port":"25565"}`},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values, err := newFillForm().Fill(context.Background(), test.sources...)
+			if test.invalid == nil {
+				if err != nil {
+					t.Fatalf("Fill returned %v", err)
+				}
+				if !maps.Equal(values, test.values) {
+					t.Errorf("values are %v, expected %v", values, test.values)
+				}
+				return
+			}
+			var fillErr *FillError
+			if !errors.As(err, &fillErr) {
+				t.Fatalf("Fill returned %v, expected a *FillError", err)
+			}
+			var invalid []string
+			for _, fieldError := range fillErr.Errors {
+				invalid = append(invalid, fieldError.FieldId)
+			}
+			if !slices.Equal(invalid, test.invalid) || !slices.Equal(fillErr.MissingFields(), test.missing) {
+				t.Errorf("invalid fields are %v and missing fields %v, expected %v and %v", invalid, fillErr.MissingFields(), test.invalid, test.missing)
+			}
+		})
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		args   []string
+		values MapSource
+		err    bool
+	}{
+		{args: []string{"--name=lobby", "--ram", "4096", "port=25566"}, values: MapSource{"name": "lobby", "ram": "4096", "port": "25566"}},
+		{args: []string{"--motd=a=b", "--empty="}, values: MapSource{"motd": "a=b", "empty": ""}},
+		{args: []string{"--name"}, err: true},
+		{args: []string{"lobby"}, err: true},
+		{args: []string{"=lobby"}, err: true},
+	}
+	for _, test := range tests {
+		values, err := ParseArgs(test.args)
+		if test.err != (err != nil) || !maps.Equal(values, test.values) {
+			t.Errorf("ParseArgs(%q) returned %v (error: %v), expected %v", test.args, values, err, test.values)
+		}
+	}
+}
+
+func TestParseAnswers(t *testing.T) {
+	values, err := ParseAnswers([]byte(`{"name": "lobby", "ram": 4096, "online": true, "motd": null}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (MapSource{"name": "lobby", "ram": "4096", "online": "true"}); !maps.Equal(values, expected) {
+		t.Errorf("answers are %v, expected %v", values, expected)
+	}
+	if _, err := ParseAnswers([]byte(`{"plugins": ["worldedit"]}`)); err == nil {
+		t.Errorf("ParseAnswers accepted a list")
+	}
+}
+
+func TestEnvVariableName(t *testing.T) {
+	tests := map[string]string{"max-ram": "SERVER_MAX_RAM", "motd": "SERVER_MOTD", "network.port": "SERVER_NETWORK_PORT"}
+	for id, expected := range tests {
+		if name := EnvVariableName("SERVER_", id); name != expected {
+			t.Errorf("EnvVariableName of %q is %q, expected %q", id, name, expected)
+		}
+	}
+	if name := EnvVariableName("", "2fa"); name != "_2FA" {
+		t.Errorf("EnvVariableName of %q is %q, expected %q", "2fa", name, "_2FA")
+	}
+}
@@ -0,0 +1,189 @@
+package go_forms
+
+import (
+	"context"
+	"flag"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FormFlags connects the fields of a form to the flags of a flag.FlagSet (see AddFlags)
+type FormFlags struct {
+	form *Form
+	// names maps the field ids to the flag names
+	names    map[string]string
+	provided map[string]bool
+}
+
+// fieldFlag is the flag.Value of a field, Set pushes the value into the form
+type fieldFlag struct {
+	field Field
+	flags *FormFlags
+}
+
+func (v *fieldFlag) String() string {
+	if v == nil || v.field == nil {
+		return ""
+	}
+	return v.field.GetValue()
+}
+
+func (v *fieldFlag) Set(value string) error {
+	switch field := v.field.(type) {
+	case *NumberField:
+		if _, err := strconv.Atoi(value); err != nil {
+			return &CustomError{Code: ErrorCodeInteger, Message: "Value is not an integer"}
+		}
+	case *MultipleChoiceField:
+		if _, ok := field.Options[value]; !ok {
+			return &CustomError{Code: ErrorCodeChoice, Message: "Value is not a valid option (options: " + strings.Join(sortedOptionKeys(field), ", ") + ")"}
+		}
+	}
+	v.field.SetValue(value)
+	v.flags.provided[v.field.GetId()] = true
+	return nil
+}
+
+// AddFlags adds one flag per input field of the form to the flag set and returns the FormFlags to check the parsed values.
+// The flag names are the field ids with the given prefix, fields nested in groups are prefixed with the group ids (e.g. "network-port").
+// Messages, groups and read-only fields get no flag. Hidden fields get a flag too because they may be displayed depending on the other values.
+// The values are set with SetValue while the flags are parsed, numbers and options are checked right away.
+func (f *Form) AddFlags(flagSet *flag.FlagSet, prefix string) *FormFlags {
+	formFlags := &FormFlags{form: f, names: make(map[string]string), provided: make(map[string]bool)}
+	formFlags.addFlags(flagSet, prefix, f.Fields)
+	return formFlags
+}
+
+// NewFlagSet creates a flag set with the flags of all input fields of the form (see AddFlags)
+func (f *Form) NewFlagSet(name string, errorHandling flag.ErrorHandling) (*flag.FlagSet, *FormFlags) {
+	flagSet := flag.NewFlagSet(name, errorHandling)
+	return flagSet, f.AddFlags(flagSet, "")
+}
+
+func (f *FormFlags) addFlags(flagSet *flag.FlagSet, prefix string, fields []Field) {
+	for _, field := range fields {
+		switch field := field.(type) {
+		case *FieldBaseType, *Message:
+			continue
+		case *FieldGroup:
+			f.addFlags(flagSet, prefix+field.Id+"-", field.Fields)
+			continue
+		}
+		if base := getFieldBase(field); base != nil && base.IsReadOnly() {
+			continue
+		}
+		name := prefix + field.GetId()
+		f.names[field.GetId()] = name
+		flagSet.Var(&fieldFlag{field: field, flags: f}, name, flagUsage(field))
+	}
+}
+
+// flagUsage returns the help text of the flag of a field built from the prompt, the help text, the placeholder and the options
+func flagUsage(field Field) string {
+	usage := field.GetId()
+	var details []string
+	if textField, ok := field.(interface {
+		GetPrompt() string
+		GetPlaceholder() string
+	}); ok {
+		if textField.GetPrompt() != "" {
+			usage = textField.GetPrompt()
+		}
+		if textField.GetPlaceholder() != "" {
+			details = append(details, "e.g. "+textField.GetPlaceholder())
+		}
+	}
+	if base := getFieldBase(field); base != nil && base.GetHelpText() != "" {
+		usage += ": " + base.GetHelpText()
+	}
+	if base := getFieldBase(field); base != nil && base.IsRequired() {
+		details = append(details, "required")
+	}
+	switch field := field.(type) {
+	case *NumberField:
+		// The back quotes make flag.PrintDefaults show "integer" as the type of the flag
+		details = append(details, "`integer`")
+	case *MultipleChoiceField:
+		options := make([]string, 0, len(field.Options))
+		for _, key := range sortedOptionKeys(field) {
+			options = append(options, key+" = "+field.Options[key].Label)
+		}
+		details = append(details, "one of: "+strings.Join(options, ", "))
+	}
+	if len(details) > 0 {
+		usage += " (" + strings.Join(details, "; ") + ")"
+	}
+	return usage
+}
+
+// FlagName returns the name of the flag of the field with the given id or an empty string if the field has no flag
+func (f *FormFlags) FlagName(id string) string {
+	return f.names[id]
+}
+
+// Provided returns the ids of the fields that were set on the command line in the order of the form
+func (f *FormFlags) Provided() []string {
+	var ids []string
+	for _, field := range f.form.GetAllFields() {
+		if f.provided[field.GetId()] {
+			ids = append(ids, field.GetId())
+		}
+	}
+	return ids
+}
+
+// Missing returns the ids of the displayed fields with a flag that were not set on the command line, e.g. to ask for them interactively
+func (f *FormFlags) Missing() []string {
+	var ids []string
+	for _, field := range f.form.GetAllFields() {
+		if _, ok := f.names[field.GetId()]; ok && !f.provided[field.GetId()] && isDisplayed(f.form, field) {
+			ids = append(ids, field.GetId())
+		}
+	}
+	return ids
+}
+
+// Validate validates the displayed fields that were set on the command line and returns a *FillError listing the invalid ones
+func (f *FormFlags) Validate(ctx context.Context) error {
+	if err := f.form.waitAsyncValidation(ctx); err != nil {
+		return err
+	}
+	var errors []*FieldError
+	for _, id := range f.Provided() {
+		field := f.form.lookupField(id)
+		if isDisplayed(f.form, field) && !field.IsValid() {
+			errors = append(errors, &FieldError{FieldId: id, Err: field.GetError()})
+		}
+	}
+	if len(errors) > 0 {
+		return &FillError{Errors: errors}
+	}
+	return nil
+}
+
+// isDisplayed returns true if the field and the groups (and wizard page) containing it should be displayed
+func isDisplayed(form *Form, field Field) bool {
+	if !field.ShouldDisplay() {
+		return false
+	}
+	for _, page := range form.pages {
+		if slices.ContainsFunc(flattenFields(page.Fields), func(pageField Field) bool { return pageField == field }) {
+			return page.ShouldDisplay() && isDisplayedIn(page.Fields, field)
+		}
+	}
+	return isDisplayedIn(form.Fields, field)
+}
+
+// isDisplayedIn returns false if the field is nested in a group of the given fields that should not be displayed
+func isDisplayedIn(fields []Field, field Field) bool {
+	for _, candidate := range fields {
+		if candidate == field {
+			return true
+		}
+		if group, ok := candidate.(*FieldGroup); ok && slices.ContainsFunc(flattenFields(group.Fields), func(nested Field) bool { return nested == field }) {
+			return group.ShouldDisplay() && isDisplayedIn(group.Fields, field)
+		}
+	}
+	return false
+}
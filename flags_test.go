@@ -0,0 +1,109 @@
+package go_forms
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		err      string
+		provided []string
+		missing  []string
+		invalid  []string
+	}{
+		{
+			name:     "values",
+			args:     []string{"-name", "lobby", "-network-port=25566"},
+			provided: []string{"name", "port"},
+			missing:  []string{"software", "ram"},
+		},
+		{
+			name:    "number",
+			args:    []string{"-ram", "lots"},
+			err:     "Value is not an integer",
+			missing: []string{"name", "software", "ram", "port"},
+		},
+		{
+			name:    "option",
+			args:    []string{"-software", "forge"},
+			err:     "options: paper, vanilla",
+			missing: []string{"name", "software", "ram", "port"},
+		},
+		{
+			name:     "hidden fields are not missing",
+			args:     []string{"-software", "vanilla"},
+			provided: []string{"software"},
+			missing:  []string{"name", "port"},
+		},
+		{
+			name:     "invalid values",
+			args:     []string{"-name", "a very long name", "-ram", "512"},
+			provided: []string{"name", "ram"},
+			missing:  []string{"software", "port"},
+			invalid:  []string{"name", "ram"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			form := newFillForm()
+			flagSet, formFlags := form.NewFlagSet("server", flag.ContinueOnError)
+			flagSet.SetOutput(io.Discard)
+			err := flagSet.Parse(test.args)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("Parse returned %v, expected the error %q", err, test.err)
+				}
+			} else if err != nil {
+				t.Fatalf("Parse returned %v", err)
+			}
+			if !slices.Equal(formFlags.Provided(), test.provided) || !slices.Equal(formFlags.Missing(), test.missing) {
+				t.Errorf("provided fields are %v and missing fields %v, expected %v and %v", formFlags.Provided(), formFlags.Missing(), test.provided, test.missing)
+			}
+			err = formFlags.Validate(context.Background())
+			var invalid []string
+			var fillErr *FillError
+			if errors.As(err, &fillErr) {
+				for _, fieldError := range fillErr.Errors {
+					invalid = append(invalid, fieldError.FieldId)
+				}
+			} else if err != nil {
+				t.Fatalf("Validate returned %v", err)
+			}
+			if !slices.Equal(invalid, test.invalid) {
+				t.Errorf("invalid fields are %v, expected %v", invalid, test.invalid)
+			}
+		})
+	}
+}
+
+func TestFlagNamesAndUsage(t *testing.T) {
+	slug := NewComputedField("slug", nil, nil, "Slug", []string{"name"}, func(values map[string]string) string { return values["name"] }, true)
+	form := NewForm(append(newFillForm().Fields, NewMessage("motd", nil, "Welcome"), slug)...)
+	form.lookupField("name").(*TextField).HelpText = "Shown in the server list"
+	flagSet := flag.NewFlagSet("server", flag.ContinueOnError)
+	formFlags := form.AddFlags(flagSet, "server-")
+	names := map[string]string{"name": "server-name", "port": "server-network-port", "motd": "", "slug": "", "network": ""}
+	for id, expected := range names {
+		if name := formFlags.FlagName(id); name != expected {
+			t.Errorf("flag of %s is %q, expected %q", id, name, expected)
+		}
+	}
+	usages := map[string]string{
+		"server-name":     "Name: Shown in the server list (required)",
+		"server-software": "Software (one of: paper = Paper, vanilla = Vanilla)",
+		"server-ram":      "RAM (`integer`)",
+	}
+	for name, expected := range usages {
+		if usage := flagSet.Lookup(name).Usage; usage != expected {
+			t.Errorf("usage of %s is %q, expected %q", name, usage, expected)
+		}
+	}
+}
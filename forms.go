@@ -1,10 +1,19 @@
 package go_forms
 
 import (
+	"cmp"
 	"encoding/json"
 	"net"
+	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
 )
 
 type Field interface {
@@ -24,21 +33,53 @@ type DisplayCondition interface {
 	DisplayCondition(field any) bool
 }
 
+// DependentValidator is implemented by validators that look at other fields.
+// The returned field ids tell the form which fields have to be re-validated when one of them changes.
+type DependentValidator interface {
+	Validator
+	GetDependencies() []string
+}
+
 // Defining the Base Field Type
 
 type FieldBaseType struct {
 	Id                string
 	DisplayConditions []DisplayCondition
-	Validators        []Validator
-	Value             string
-	form              *Form
-	error             error
+	// HelpText explains the field, renderers show it below or next to the input
+	HelpText string
+	// Required fields must not be empty, RequiredIf makes the field required if all of the conditions are met (see IsRequired)
+	Required   bool
+	RequiredIf []DisplayCondition
+	// ReadOnly fields are displayed but cannot be changed by the user, DisableConditions disable the field if all of them are met (see IsDisabled)
+	ReadOnly           bool
+	DisableConditions  []DisplayCondition
+	DisabledValidation DisabledValidationPolicy
+	Validators         []Validator
+	AsyncValidators    []AsyncValidator
+	Normalizers        []Normalizer
+	Debounce           time.Duration
+	// Default is the value the field is reset to (see Form.Reset). DefaultFunc computes it from the values of the form instead,
+	// e.g. to derive it from other fields or the environment, it is also applied when the form is created.
+	Default     string
+	DefaultFunc func(values map[string]string) string
+	// defaultEnv is the environment variable read by the DefaultFunc of fields loaded from a schema (see FieldSchema.DefaultEnv)
+	defaultEnv string
+	// Value must not be written directly once the field is used concurrently, use SetValue instead
+	Value string
+	form  *Form
+	error error
+	mu    sync.RWMutex
+	async asyncValidation
 }
 
 func (f *FieldBaseType) GetId() string {
 	return f.Id
 }
 
+func (f *FieldBaseType) GetHelpText() string {
+	return f.HelpText
+}
+
 func (f *FieldBaseType) ShouldDisplay() bool {
 	for _, displayCondition := range f.DisplayConditions {
 		if !displayCondition.DisplayCondition(f) {
@@ -48,34 +89,197 @@ func (f *FieldBaseType) ShouldDisplay() bool {
 	return true
 }
 
+// IsRequired returns true if the field is Required, all RequiredIf conditions are met or a NotEmptyValidator is used.
+// Renderers use it to mark the field, e.g. with an asterisk.
+func (f *FieldBaseType) IsRequired() bool {
+	return f.isRequiredByCondition() || slices.ContainsFunc(f.Validators, func(validator Validator) bool {
+		_, ok := validator.(*NotEmptyValidator)
+		return ok
+	})
+}
+
+func (f *FieldBaseType) isRequiredByCondition() bool {
+	if f.Required {
+		return true
+	}
+	if len(f.RequiredIf) == 0 {
+		return false
+	}
+	for _, condition := range f.RequiredIf {
+		if !condition.DisplayCondition(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// DisabledValidationPolicy defines if disabled fields are validated
+type DisabledValidationPolicy int
+
+const (
+	// SkipDisabledValidation treats disabled fields as valid (default)
+	SkipDisabledValidation DisabledValidationPolicy = iota
+	// ValidateDisabled validates disabled fields like enabled ones
+	ValidateDisabled
+)
+
+func (f *FieldBaseType) IsReadOnly() bool {
+	return f.ReadOnly
+}
+
+// IsDisabled returns true if the field has DisableConditions and all of them are met
+func (f *FieldBaseType) IsDisabled() bool {
+	if len(f.DisableConditions) == 0 {
+		return false
+	}
+	for _, condition := range f.DisableConditions {
+		if !condition.DisplayCondition(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEditable returns true if the user may change the value, i.e. the field is neither read-only nor disabled
+func (f *FieldBaseType) IsEditable() bool {
+	return !f.ReadOnly && !f.IsDisabled()
+}
+
 func (f *FieldBaseType) IsValid() bool {
+	return f.validate(f)
+}
+
+// validate checks that required fields are not empty and runs the validators with the given field, which embeds f
+func (f *FieldBaseType) validate(field any) bool {
 	if !f.ShouldDisplay() {
 		return true
 	}
+	if f.DisabledValidation == SkipDisabledValidation && f.IsDisabled() {
+		f.setError(nil)
+		return true
+	}
+	// A NotEmptyValidator reports the empty value itself
+	if f.GetValue() == "" && f.isRequiredByCondition() {
+		f.setError(&CustomError{Code: ErrorCodeRequired, Message: "Field is required"})
+		return false
+	}
 	for _, validator := range f.Validators {
-		if !validator.Validate(f) {
+		if !validator.Validate(field) {
 			return false
 		}
 	}
-	f.error = nil
+	if !f.isAsyncValid() {
+		return false
+	}
+	f.setError(nil)
 	return true
 }
 
+// checkValid validates the field like IsValid but keeps its previous error, e.g. to know if a form can be submitted without changing
+// the errors of fields whose errors are not shown yet
+func checkValid(field Field) bool {
+	base := getFieldBase(field)
+	if base == nil {
+		return field.IsValid()
+	}
+	previous := base.GetError()
+	valid := field.IsValid()
+	base.setError(previous)
+	return valid
+}
+
+// GetDefault returns the value the field is reset to
+func (f *FieldBaseType) GetDefault() string {
+	if f.DefaultFunc == nil {
+		return f.Default
+	}
+	values := make(map[string]string)
+	if f.form != nil {
+		values = f.form.getAllFieldValues()
+	}
+	return f.defaultFrom(values)
+}
+
+// defaultFrom returns the value the field is reset to with the DefaultFunc computing it from the given values
+func (f *FieldBaseType) defaultFrom(values map[string]string) string {
+	if f.DefaultFunc == nil {
+		return f.Default
+	}
+	return f.DefaultFunc(values)
+}
+
 func (f *FieldBaseType) GetValue() string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
 	return f.Value
 }
 
+// SetValue normalizes and stores the value, updates the computed fields and calls the change callbacks of the form.
+// It is safe to call SetValue from any goroutine and from validators or callbacks, changes of the same form are applied one after another.
 func (f *FieldBaseType) SetValue(value string) {
-	f.Value = value
-	if f.form != nil {
-		f.form.onChange()
+	value = f.normalize(value)
+	if f.form == nil {
+		if f.storeValue(value) != value {
+			f.startAsyncValidation(f.Debounce)
+		}
+		return
+	}
+	f.form.mu.Lock()
+	oldValue := f.storeValue(value)
+	if oldValue == value {
+		f.form.mu.Unlock()
+		return
 	}
+	step := f.form.recordChange(f.Id, oldValue, value)
+	f.form.propagate(pendingChange{ids: []string{f.Id}, step: step})
+}
+
+// normalize applies the normalizers of the field to the value
+func (f *FieldBaseType) normalize(value string) string {
+	for _, normalizer := range f.Normalizers {
+		value = normalizer.Normalize(value)
+	}
+	return value
 }
 
 func (f *FieldBaseType) GetError() error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
 	return f.error
 }
 
+// storeValue sets the value without notifying the form and returns the previous value
+func (f *FieldBaseType) storeValue(value string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	oldValue := f.Value
+	f.Value = value
+	return oldValue
+}
+
+func (f *FieldBaseType) setError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.error = err
+}
+
+func (f *FieldBaseType) getFieldBase() *FieldBaseType {
+	return f
+}
+
+// fieldBaseProvider is implemented by every field type embedding FieldBaseType
+type fieldBaseProvider interface {
+	getFieldBase() *FieldBaseType
+}
+
+// getFieldBase returns the FieldBaseType of the given field or nil if it does not embed one
+func getFieldBase(field any) *FieldBaseType {
+	if provider, ok := field.(fieldBaseProvider); ok {
+		return provider.getFieldBase()
+	}
+	return nil
+}
+
 type CustomValidator struct {
 	Validator func(field any) (bool, error)
 }
@@ -83,7 +287,7 @@ type CustomValidator struct {
 func (v *CustomValidator) Validate(field any) bool {
 	valid, err := v.Validator(field)
 	if err != nil {
-		field.(*FieldBaseType).error = err
+		getFieldBase(field).setError(err)
 	}
 	return valid
 }
@@ -91,10 +295,10 @@ func (v *CustomValidator) Validate(field any) bool {
 type AllFieldsValid struct{}
 
 func (v *AllFieldsValid) Validate(field any) bool {
-	fields := field.(*FieldBaseType).form.GetAllFields()
+	fields := getFieldBase(field).form.GetAllFields()
 	for _, f := range fields {
 		if !f.IsValid() {
-			field.(*FieldBaseType).error = &CustomError{Message: "Not all fields are valid (invalid field: " + f.GetId() + ")"}
+			getFieldBase(field).setError(&CustomError{Code: ErrorCodeAllFieldsValid, Message: "Not all fields are valid (invalid field: " + f.GetId() + ")"})
 			return false
 		}
 	}
@@ -106,11 +310,11 @@ type IsValidValidator struct {
 }
 
 func (v *IsValidValidator) Validate(field any) bool {
-	fields := field.(*FieldBaseType).form.GetAllFields()
+	fields := getFieldBase(field).form.GetAllFields()
 	for _, f := range fields {
 		for _, id := range v.FieldIds {
 			if f.GetId() == id && !f.IsValid() {
-				field.(*FieldBaseType).error = &CustomError{Message: "Not all fields that should be valid are valid (invalid field: " + f.GetId() + ")"}
+				getFieldBase(field).setError(&CustomError{Code: ErrorCodeIsValid, Message: "Not all fields that should be valid are valid (invalid field: " + f.GetId() + ")"})
 				return false
 			}
 		}
@@ -118,6 +322,186 @@ func (v *IsValidValidator) Validate(field any) bool {
 	return true
 }
 
+// Defining the cross-field Validators comparing the value of a field with other fields
+
+type EqualsFieldValidator struct {
+	FieldId string
+}
+
+func (v *EqualsFieldValidator) GetDependencies() []string {
+	return []string{v.FieldId}
+}
+
+func (v *EqualsFieldValidator) Validate(field any) bool {
+	base := getFieldBase(field)
+	other := base.form.lookupField(v.FieldId)
+	valid := other != nil && base.GetValue() == other.GetValue()
+	if !valid {
+		base.setError(&CustomError{Code: ErrorCodeEqualsField, Message: "Field value does not match " + v.FieldId})
+	}
+	return valid
+}
+
+type NotEqualsFieldValidator struct {
+	FieldId string
+}
+
+func (v *NotEqualsFieldValidator) GetDependencies() []string {
+	return []string{v.FieldId}
+}
+
+func (v *NotEqualsFieldValidator) Validate(field any) bool {
+	base := getFieldBase(field)
+	other := base.form.lookupField(v.FieldId)
+	valid := other == nil || base.GetValue() != other.GetValue()
+	if !valid {
+		base.setError(&CustomError{Code: ErrorCodeNotEqualsField, Message: "Field value must be different from " + v.FieldId})
+	}
+	return valid
+}
+
+// GreaterThanFieldValidator compares the value numerically or, if Layout is set, as dates parsed with time.Parse
+type GreaterThanFieldValidator struct {
+	FieldId string
+	Layout  string
+}
+
+func (v *GreaterThanFieldValidator) GetDependencies() []string {
+	return []string{v.FieldId}
+}
+
+func (v *GreaterThanFieldValidator) Validate(field any) bool {
+	return validateComparison(field, v.FieldId, v.Layout, func(result int) bool { return result > 0 }, "greater than", ErrorCodeGreaterThanField)
+}
+
+// LessThanFieldValidator compares the value numerically or, if Layout is set, as dates parsed with time.Parse
+type LessThanFieldValidator struct {
+	FieldId string
+	Layout  string
+}
+
+func (v *LessThanFieldValidator) GetDependencies() []string {
+	return []string{v.FieldId}
+}
+
+func (v *LessThanFieldValidator) Validate(field any) bool {
+	return validateComparison(field, v.FieldId, v.Layout, func(result int) bool { return result < 0 }, "less than", ErrorCodeLessThanField)
+}
+
+func validateComparison(field any, otherId string, layout string, accept func(result int) bool, relation string, code string) bool {
+	base := getFieldBase(field)
+	other := base.form.lookupField(otherId)
+	if other == nil || base.GetValue() == "" || other.GetValue() == "" {
+		return true
+	}
+	result, err := compareValues(base.GetValue(), other.GetValue(), layout)
+	if err != nil {
+		base.setError(&CustomError{Code: ErrorCodeNotComparable, Message: "Field value cannot be compared with " + otherId + " (" + err.Error() + ")"})
+		return false
+	}
+	valid := accept(result)
+	if !valid {
+		base.setError(&CustomError{Code: code, Message: "Field value must be " + relation + " the value of " + otherId + " (value: " + base.GetValue() + ", " + otherId + ": " + other.GetValue() + ")"})
+	}
+	return valid
+}
+
+// compareValues compares two values as dates if a layout is given and as numbers otherwise
+func compareValues(a string, b string, layout string) (int, error) {
+	if layout != "" {
+		timeA, err := time.Parse(layout, a)
+		if err != nil {
+			return 0, err
+		}
+		timeB, err := time.Parse(layout, b)
+		if err != nil {
+			return 0, err
+		}
+		return timeA.Compare(timeB), nil
+	}
+	numberA, err := strconv.ParseFloat(a, 64)
+	if err != nil {
+		return 0, err
+	}
+	numberB, err := strconv.ParseFloat(b, 64)
+	if err != nil {
+		return 0, err
+	}
+	return cmp.Compare(numberA, numberB), nil
+}
+
+// RequiredIfValidator requires a value if the field FieldId has the given Value (or any value if Value is empty)
+type RequiredIfValidator struct {
+	FieldId string
+	Value   string
+}
+
+func (v *RequiredIfValidator) GetDependencies() []string {
+	return []string{v.FieldId}
+}
+
+func (v *RequiredIfValidator) Validate(field any) bool {
+	base := getFieldBase(field)
+	if base.GetValue() != "" || !otherFieldHasValue(base.form, v.FieldId, v.Value) {
+		return true
+	}
+	base.setError(&CustomError{Code: ErrorCodeRequiredIf, Message: "Field is required because " + v.FieldId + " is set"})
+	return false
+}
+
+// RequiredUnlessValidator requires a value unless the field FieldId has the given Value (or any value if Value is empty)
+type RequiredUnlessValidator struct {
+	FieldId string
+	Value   string
+}
+
+func (v *RequiredUnlessValidator) GetDependencies() []string {
+	return []string{v.FieldId}
+}
+
+func (v *RequiredUnlessValidator) Validate(field any) bool {
+	base := getFieldBase(field)
+	if base.GetValue() != "" || otherFieldHasValue(base.form, v.FieldId, v.Value) {
+		return true
+	}
+	base.setError(&CustomError{Code: ErrorCodeRequiredUnless, Message: "Field is required unless " + v.FieldId + " is set"})
+	return false
+}
+
+func otherFieldHasValue(form *Form, id string, value string) bool {
+	other := form.lookupField(id)
+	if other == nil {
+		return false
+	}
+	if value == "" {
+		return other.GetValue() != ""
+	}
+	return other.GetValue() == value
+}
+
+// MutuallyExclusiveValidator fails if the field and any of the fields FieldIds have a value at the same time
+type MutuallyExclusiveValidator struct {
+	FieldIds []string
+}
+
+func (v *MutuallyExclusiveValidator) GetDependencies() []string {
+	return v.FieldIds
+}
+
+func (v *MutuallyExclusiveValidator) Validate(field any) bool {
+	base := getFieldBase(field)
+	if base.GetValue() == "" {
+		return true
+	}
+	for _, id := range v.FieldIds {
+		if otherFieldHasValue(base.form, id, "") {
+			base.setError(&CustomError{Code: ErrorCodeMutuallyExclusive, Message: "Field cannot be combined with " + id})
+			return false
+		}
+	}
+	return true
+}
+
 type AlwaysDisplay struct{}
 
 func (d *AlwaysDisplay) DisplayCondition(_ any) bool {
@@ -137,7 +521,7 @@ type IsValidDisplayCondition struct {
 }
 
 func (d *IsValidDisplayCondition) DisplayCondition(field any) bool {
-	fields := field.(*FieldBaseType).form.GetAllFields()
+	fields := getFieldBase(field).form.GetAllFields()
 	for _, f := range fields {
 		for _, id := range d.FieldIds {
 			if f.GetId() == id && !f.IsValid() {
@@ -153,7 +537,7 @@ type IsInvalidDisplayCondition struct {
 }
 
 func (d *IsInvalidDisplayCondition) DisplayCondition(field any) bool {
-	fields := field.(*FieldBaseType).form.GetAllFields()
+	fields := getFieldBase(field).form.GetAllFields()
 	for _, f := range fields {
 		for _, id := range d.FieldIds {
 			if f.GetId() == id && f.IsValid() {
@@ -167,7 +551,7 @@ func (d *IsInvalidDisplayCondition) DisplayCondition(field any) bool {
 type AllFieldsValidDisplayCondition struct{}
 
 func (d *AllFieldsValidDisplayCondition) DisplayCondition(field any) bool {
-	fields := field.(*FieldBaseType).form.GetAllFields()
+	fields := getFieldBase(field).form.GetAllFields()
 	for _, f := range fields {
 		if !f.IsValid() {
 			return false
@@ -182,7 +566,7 @@ type HasValueDisplayCondition struct {
 }
 
 func (d *HasValueDisplayCondition) DisplayCondition(field any) bool {
-	fields := field.(*FieldBaseType).form.GetAllFields()
+	fields := getFieldBase(field).form.GetAllFields()
 	for _, f := range fields {
 		if f.GetId() == d.FieldId && f.GetValue() == d.Value {
 			return true
@@ -197,7 +581,7 @@ type DisplayAfter struct {
 }
 
 func (d *DisplayAfter) DisplayCondition(field any) bool {
-	fields := field.(*FieldBaseType).form.GetAllFields()
+	fields := getFieldBase(field).form.GetAllFields()
 	for _, f := range fields {
 		if f.GetId() == d.FieldId {
 			return f.IsValid() && f.ShouldDisplay()
@@ -249,36 +633,66 @@ type TextField struct {
 type NotEmptyValidator struct{}
 
 func (v *NotEmptyValidator) Validate(field any) bool {
-	value := field.(*FieldBaseType).Value
+	value := getFieldBase(field).GetValue()
 	valid := value != ""
 	if !valid {
-		field.(*FieldBaseType).error = &CustomError{Message: "Field cannot be empty"}
+		getFieldBase(field).setError(&CustomError{Code: ErrorCodeNotEmpty, Message: "Field cannot be empty"})
 	}
 	return valid
 }
 
+// LengthUnit defines what the length validators count
+type LengthUnit int
+
+const (
+	// LengthInRunes counts unicode code points, e.g. "Größe" has a length of 5
+	LengthInRunes LengthUnit = iota
+	// LengthInGraphemes counts user-perceived characters, e.g. a flag emoji made of two code points has a length of 1
+	LengthInGraphemes
+	// LengthInBytes counts the bytes of the UTF-8 encoded value
+	LengthInBytes
+)
+
+func (u LengthUnit) Length(value string) int {
+	switch u {
+	case LengthInGraphemes:
+		return uniseg.GraphemeClusterCount(value)
+	case LengthInBytes:
+		return len(value)
+	default:
+		return utf8.RuneCountInString(value)
+	}
+}
+
 type MaxLengthValidator struct {
 	MaxLength int
+	Unit      LengthUnit
 }
 
 func (v *MaxLengthValidator) Validate(field any) bool {
-	value := field.(*FieldBaseType).Value
-	valid := len(value) <= v.MaxLength
+	value := getFieldBase(field).GetValue()
+	length := v.Unit.Length(value)
+	valid := length <= v.MaxLength
 	if !valid {
-		field.(*FieldBaseType).error = &CustomError{Message: "Field is too long (length: " + strconv.Itoa(len(value)) + ", max length: " + strconv.Itoa(v.MaxLength) + ")"}
+		getFieldBase(field).setError(&CustomError{Code: ErrorCodeMaxLength, Message: "Field is too long (length: " + strconv.Itoa(length) + ", max length: " + strconv.Itoa(v.MaxLength) + ")"})
 	}
 	return valid
 }
 
 type MinLengthValidator struct {
 	MinLength int
+	Unit      LengthUnit
 }
 
 func (v *MinLengthValidator) Validate(field any) bool {
-	value := field.(*FieldBaseType).Value
-	valid := len(value) >= v.MinLength
+	value := getFieldBase(field).GetValue()
+	if value == "" {
+		return true
+	}
+	length := v.Unit.Length(value)
+	valid := length >= v.MinLength
 	if !valid {
-		field.(*FieldBaseType).error = &CustomError{Message: "Field is too short (length: " + strconv.Itoa(len(value)) + ", min length: " + strconv.Itoa(v.MinLength) + ")"}
+		getFieldBase(field).setError(&CustomError{Code: ErrorCodeMinLength, Message: "Field is too short (length: " + strconv.Itoa(length) + ", min length: " + strconv.Itoa(v.MinLength) + ")"})
 	}
 	return valid
 }
@@ -286,10 +700,13 @@ func (v *MinLengthValidator) Validate(field any) bool {
 type IpValidator struct{}
 
 func (v *IpValidator) Validate(field any) bool {
-	value := field.(*FieldBaseType).Value
+	value := getFieldBase(field).GetValue()
+	if value == "" {
+		return true
+	}
 	valid := net.ParseIP(value) != nil
 	if !valid {
-		field.(*FieldBaseType).error = &CustomError{Message: "Field is not a valid IP address"}
+		getFieldBase(field).setError(&CustomError{Code: ErrorCodeIp, Message: "Field is not a valid IP address"})
 	}
 	return valid
 }
@@ -299,26 +716,41 @@ type RegexValidator struct {
 }
 
 func (v *RegexValidator) Validate(field any) bool {
-	value := field.(*FieldBaseType).Value
+	value := getFieldBase(field).GetValue()
 	valid := true
 	if value != "" {
 		valid = regexp.MustCompile(v.RegexPattern).MatchString(value)
 	}
 	if !valid {
-		field.(*FieldBaseType).error = &CustomError{Message: "Field does not match the required pattern (" + v.RegexPattern + ")"}
+		getFieldBase(field).setError(&CustomError{Code: ErrorCodeRegex, Message: "Field does not match the required pattern (" + v.RegexPattern + ")"})
 	}
 	return valid
 }
 
-type UrlValidator struct{}
+// UrlValidator parses the value as absolute URL with a host. AllowedSchemes defaults to http and https.
+type UrlValidator struct {
+	AllowedSchemes []string
+}
 
 func (v *UrlValidator) Validate(field any) bool {
-	value := field.(*FieldBaseType).Value
-	valid := regexp.MustCompile(`^https?://.`).MatchString(value)
-	if !valid {
-		field.(*FieldBaseType).error = &CustomError{Message: "Field is not a valid URL"}
+	value := getFieldBase(field).GetValue()
+	if value == "" {
+		return true
 	}
-	return valid
+	parsedUrl, err := url.Parse(value)
+	if err != nil || parsedUrl.Scheme == "" || parsedUrl.Host == "" {
+		getFieldBase(field).setError(&CustomError{Code: ErrorCodeUrl, Message: "Field is not a valid URL"})
+		return false
+	}
+	allowedSchemes := v.AllowedSchemes
+	if len(allowedSchemes) == 0 {
+		allowedSchemes = []string{"http", "https"}
+	}
+	if !slices.Contains(allowedSchemes, strings.ToLower(parsedUrl.Scheme)) {
+		getFieldBase(field).setError(&CustomError{Code: ErrorCodeUrlScheme, Message: "URL scheme is not allowed (scheme: " + parsedUrl.Scheme + ", allowed schemes: " + strings.Join(allowedSchemes, ", ") + ")"})
+		return false
+	}
+	return true
 }
 
 func (t *TextField) GetPlaceholder() string {
@@ -340,15 +772,18 @@ type MinValidator struct {
 }
 
 func (v *MinValidator) Validate(field any) bool {
-	value := field.(*FieldBaseType).Value
+	value := getFieldBase(field).GetValue()
+	if value == "" {
+		return true
+	}
 	valueAsInt, err := strconv.Atoi(value)
 	if err != nil {
-		field.(*FieldBaseType).error = &CustomError{Message: "Field value is not a integer"}
+		getFieldBase(field).setError(&CustomError{Code: ErrorCodeInteger, Message: "Field value is not a integer"})
 		return false
 	}
 	valid := v.Min <= valueAsInt
 	if !valid {
-		field.(*FieldBaseType).error = &CustomError{Message: "Field value is too small (value: " + value + ", min value: " + strconv.Itoa(v.Min) + ")"}
+		getFieldBase(field).setError(&CustomError{Code: ErrorCodeMin, Message: "Field value is too small (value: " + value + ", min value: " + strconv.Itoa(v.Min) + ")"})
 	}
 	return valid
 }
@@ -358,15 +793,18 @@ type MaxValidator struct {
 }
 
 func (v *MaxValidator) Validate(field any) bool {
-	value := field.(*FieldBaseType).Value
+	value := getFieldBase(field).GetValue()
+	if value == "" {
+		return true
+	}
 	valueAsInt, err := strconv.Atoi(value)
 	if err != nil {
-		field.(*FieldBaseType).error = &CustomError{Message: "Field value is not a integer"}
+		getFieldBase(field).setError(&CustomError{Code: ErrorCodeInteger, Message: "Field value is not a integer"})
 		return false
 	}
 	valid := valueAsInt <= v.Max
 	if !valid {
-		field.(*FieldBaseType).error = &CustomError{Message: "Field value is too big (value: " + value + ", max value: " + strconv.Itoa(v.Max) + ")"}
+		getFieldBase(field).setError(&CustomError{Code: ErrorCodeMax, Message: "Field value is too big (value: " + value + ", max value: " + strconv.Itoa(v.Max) + ")"})
 	}
 	return valueAsInt <= v.Max
 }
@@ -374,10 +812,13 @@ func (v *MaxValidator) Validate(field any) bool {
 type IsIntegerValidator struct{}
 
 func (v *IsIntegerValidator) Validate(field any) bool {
-	value := field.(*FieldBaseType).Value
+	value := getFieldBase(field).GetValue()
+	if value == "" {
+		return true
+	}
 	_, err := strconv.Atoi(value)
 	if err != nil {
-		field.(*FieldBaseType).error = &CustomError{Message: "Field value is not a integer"}
+		getFieldBase(field).setError(&CustomError{Code: ErrorCodeInteger, Message: "Field value is not a integer"})
 	}
 	return err == nil
 }
@@ -399,12 +840,15 @@ type ChoiceValidator struct{}
 func (v *ChoiceValidator) Validate(field any) bool {
 	multipleChoiceField, ok := field.(*MultipleChoiceField)
 	if !ok {
-		multipleChoiceField.error = &CustomError{Message: "Field is not a multiple choice field but ChoiceValidator was used"}
+		getFieldBase(field).setError(&CustomError{Code: ErrorCodeChoice, Message: "Field is not a multiple choice field but ChoiceValidator was used"})
 		return false
 	}
-	_, ok = multipleChoiceField.Options[multipleChoiceField.Value]
+	if multipleChoiceField.GetValue() == "" {
+		return true
+	}
+	_, ok = multipleChoiceField.Options[multipleChoiceField.GetValue()]
 	if !ok {
-		multipleChoiceField.error = &CustomError{Message: "Field value is not a valid option"}
+		multipleChoiceField.setError(&CustomError{Code: ErrorCodeChoice, Message: "Field value is not a valid option"})
 	}
 	return ok
 }
@@ -414,26 +858,70 @@ func (m *MultipleChoiceField) GetOptions() map[string]Option {
 }
 
 func (m *MultipleChoiceField) IsValid() bool {
-	if !m.ShouldDisplay() {
-		return true
-	}
-	for _, validator := range m.Validators {
-		if !validator.Validate(m) {
-			return false
-		}
+	return m.validate(m)
+}
+
+// Defining the Computed Field Type based on the Text Field Type
+
+type ComputedField struct {
+	*TextField
+	DependsOn []string
+	Compute   func(values map[string]string) string
+}
+
+func (c *ComputedField) GetDependencies() []string {
+	return c.DependsOn
+}
+
+func (c *ComputedField) compute() string {
+	if c.Compute == nil || c.form == nil {
+		return c.GetValue()
 	}
-	m.error = nil
-	return true
+	return c.Compute(c.form.getAllFieldValues())
 }
 
 // Defining the Field Group Type based on the Base Field Type
 
+// GroupLayout is a hint for renderers how to arrange the fields of a FieldGroup
+type GroupLayout int
+
+const (
+	// GroupLayoutList renders the fields in the list of the surrounding fields with the heading above them (default)
+	GroupLayoutList GroupLayout = iota
+	// GroupLayoutCard renders the fields in a section with the heading as title
+	GroupLayoutCard
+	// GroupLayoutTab renders the group as tab, consecutive groups with this layout share the tabs
+	GroupLayoutTab
+	// GroupLayoutAccordion renders the group as collapsible section, consecutive groups with this layout share the accordion
+	GroupLayoutAccordion
+	// GroupLayoutGrid renders the fields in a grid with Columns columns
+	GroupLayoutGrid
+)
+
 type FieldGroup struct {
 	*FieldBaseType
-	Fields  []Field
+	Fields []Field
+	Layout GroupLayout
+	// Columns is the number of columns of GroupLayoutGrid, defaults to 2
+	Columns int
 	heading string
 }
 
+// GetTitle returns the heading of the group or its id if the group has no heading, e.g. as title of a tab
+func (f *FieldGroup) GetTitle() string {
+	if f.heading != "" {
+		return f.heading
+	}
+	return f.Id
+}
+
+func (f *FieldGroup) GetColumns() int {
+	if f.Columns < 1 {
+		return 2
+	}
+	return f.Columns
+}
+
 func (f *FieldGroup) GetFieldsToDisplay() []Field {
 	var fieldsToDisplay []Field
 	for _, field := range f.Fields {
@@ -456,6 +944,9 @@ func (f *FieldGroup) GetFieldById(id string) Field {
 func (f *FieldGroup) GetValue() string {
 	fieldValues := make(map[string]string)
 	for _, field := range f.Fields {
+		if f.form.excludesValue(field) {
+			continue
+		}
 		fieldValues[field.GetId()] = field.GetValue()
 	}
 	jsonFieldValues, _ := json.Marshal(fieldValues)
@@ -483,24 +974,229 @@ func (f *FieldGroup) SetHeading(heading string) {
 
 // Defining the Form Type
 
+// Form is safe for concurrent use: changes of values are recorded while mu is locked and applied one after another (see propagate),
+// the remaining state is guarded by stateMu. Callbacks are delivered through the dispatcher after the changes are done (see SetCallbackDispatcher).
 type Form struct {
-	Fields   []Field
-	onChange func()
+	Fields              []Field
+	onChange            func()
+	changeListeners     []*func()
+	validationListeners []*func(fieldId string)
+	dispatch            func(callback func())
+	pages               []*WizardPage
+	currentPage         int
+	autoSave            func(draft []byte)
+	history             history
+	mu                  sync.Mutex
+	stateMu             sync.RWMutex
+	// pristine contains the values IsDirty compares with, touched the fields the user left (see MarkTouched)
+	pristine          map[string]string
+	touched           map[string]bool
+	validationMode    ValidationMode
+	hiddenFieldPolicy HiddenFieldPolicy
+	// hidden contains the fields that were hidden after the last change, it is guarded by mu (see applyHiddenFieldPolicy)
+	hidden map[string]bool
+	// pending contains the changes whose consequences are not applied yet, propagating is set while they are applied. Both are guarded by mu.
+	pending     []pendingChange
+	propagating bool
+	// computedFields contains the computed fields in the order they have to be computed in (see computedFieldOrder),
+	// dependents the fields depending on a field (see GetDependentFields)
+	computedFields []*ComputedField
+	dependents     map[string][]Field
 }
 
 func (f *Form) GetAllFields() []Field {
-	fields := make([]Field, 0)
-	for _, field := range f.Fields {
-		fields = append(fields, field)
+	return flattenFields(f.Fields)
+}
+
+// GetDependentFields returns the computed fields and the fields with validators depending on the field with the given id (see DependentValidator).
+// The dependencies are indexed when the form is created, the form re-validates the dependent fields after every change.
+func (f *Form) GetDependentFields(id string) []Field {
+	return slices.Clone(f.dependents[id])
+}
+
+// indexDependents maps the ids of the fields to the computed fields and the fields with validators depending on them
+func indexDependents(fields []Field) map[string][]Field {
+	dependents := make(map[string][]Field)
+	for _, field := range flattenFields(fields) {
+		var dependencies []string
+		if computed, ok := field.(*ComputedField); ok {
+			dependencies = append(dependencies, computed.DependsOn...)
+		}
+		if base := getFieldBase(field); base != nil {
+			dependencies = append(dependencies, validatorDependencies(base.Validators...)...)
+		}
+		slices.Sort(dependencies)
+		for _, id := range slices.Compact(dependencies) {
+			dependents[id] = append(dependents[id], field)
+		}
+	}
+	return dependents
+}
+
+// revalidateDependents validates the fields depending on the changed fields and returns their ids
+func (f *Form) revalidateDependents(changedIds []string) []string {
+	var revalidatedIds []string
+	for _, id := range changedIds {
+		for _, dependent := range f.dependents[id] {
+			if slices.Contains(revalidatedIds, dependent.GetId()) {
+				continue
+			}
+			dependent.IsValid()
+			revalidatedIds = append(revalidatedIds, dependent.GetId())
+		}
+	}
+	return revalidatedIds
+}
+
+// lookupField searches the field with the given id including the ones nested in groups
+func (f *Form) lookupField(id string) Field {
+	if f == nil {
+		return nil
+	}
+	for _, field := range f.GetAllFields() {
+		if field.GetId() == id {
+			return field
+		}
+	}
+	return nil
+}
+
+func flattenFields(fields []Field) []Field {
+	flattened := make([]Field, 0, len(fields))
+	for _, field := range fields {
+		flattened = append(flattened, field)
 		if group, ok := field.(*FieldGroup); ok {
-			fields = append(fields, group.Fields...)
+			flattened = append(flattened, flattenFields(group.Fields)...)
+		}
+	}
+	return flattened
+}
+
+// getParentGroups returns the groups containing the field with the given id, the outermost group first
+func (f *Form) getParentGroups(id string) []*FieldGroup {
+	return parentGroups(f.GetAllFields(), id)
+}
+
+func parentGroups(fields []Field, id string) []*FieldGroup {
+	for _, field := range fields {
+		group, ok := field.(*FieldGroup)
+		if !ok {
+			continue
+		}
+		if slices.ContainsFunc(group.Fields, func(child Field) bool { return child.GetId() == id }) {
+			return []*FieldGroup{group}
+		}
+		if parents := parentGroups(group.Fields, id); parents != nil {
+			return append([]*FieldGroup{group}, parents...)
 		}
 	}
-	return fields
+	return nil
+}
+
+// getAllFieldValues returns the values of all fields including the ones nested in groups
+func (f *Form) getAllFieldValues() map[string]string {
+	fieldValues := make(map[string]string)
+	for _, field := range f.GetAllFields() {
+		if _, ok := field.(*FieldGroup); ok {
+			continue
+		}
+		fieldValues[field.GetId()] = field.GetValue()
+	}
+	return fieldValues
+}
+
+func (f *Form) attachField(field Field) {
+	if provider, ok := field.(fieldBaseProvider); ok {
+		provider.getFieldBase().form = f
+	}
+	if group, ok := field.(*FieldGroup); ok {
+		for _, child := range group.Fields {
+			f.attachField(child)
+		}
+	}
+}
+
+// updateComputedFields updates the computed fields depending directly or indirectly on the changed fields and returns the ids of the
+// computed fields whose value changed. The fields are computed in the order of their dependencies, so every field sees the new values.
+func (f *Form) updateComputedFields(changedIds ...string) []string {
+	changed := make(map[string]bool)
+	for _, id := range changedIds {
+		changed[id] = true
+	}
+	var updatedIds []string
+	for _, computed := range f.computedFields {
+		if !slices.ContainsFunc(computed.DependsOn, func(id string) bool { return changed[id] }) {
+			continue
+		}
+		value := computed.compute()
+		if computed.storeValue(value) != value {
+			changed[computed.Id] = true
+			updatedIds = append(updatedIds, computed.Id)
+		}
+	}
+	return updatedIds
+}
+
+// recomputeAll computes the values of all computed fields in the form and returns their ids
+func (f *Form) recomputeAll() []string {
+	var computedIds []string
+	for _, computed := range f.computedFields {
+		computed.storeValue(computed.compute())
+		computedIds = append(computedIds, computed.Id)
+	}
+	return computedIds
+}
+
+// computedFieldOrder returns the computed fields of the given fields so that every computed field comes after the computed fields it
+// depends on. It returns an error if computed fields depend on each other in a cycle.
+func computedFieldOrder(fields []Field) ([]*ComputedField, error) {
+	computedFields := make(map[string]*ComputedField)
+	var ids []string
+	for _, field := range flattenFields(fields) {
+		if computed, ok := field.(*ComputedField); ok {
+			computedFields[computed.Id] = computed
+			ids = append(ids, computed.Id)
+		}
+	}
+	// visiting contains the fields on the current path, a field reached again while it is visited closes a cycle
+	visiting := make(map[string]bool)
+	done := make(map[string]bool)
+	var path []string
+	var order []*ComputedField
+	var visit func(id string) error
+	visit = func(id string) error {
+		if visiting[id] {
+			cycle := append(slices.Clone(path[slices.Index(path, id):]), id)
+			return &CustomError{Message: "Computed fields depend on each other (" + strings.Join(cycle, " -> ") + ")"}
+		}
+		if done[id] {
+			return nil
+		}
+		visiting[id] = true
+		path = append(path, id)
+		for _, dependency := range computedFields[id].DependsOn {
+			if _, ok := computedFields[dependency]; ok {
+				if err := visit(dependency); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		visiting[id] = false
+		done[id] = true
+		order = append(order, computedFields[id])
+		return nil
+	}
+	for _, id := range ids {
+		if err := visit(id); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
 }
 
 func (f *Form) IsValid() bool {
-	for _, field := range f.Fields {
+	for _, field := range f.getFieldsToValidate() {
 		if !field.IsValid() {
 			return false
 		}
@@ -518,6 +1214,9 @@ func (f *Form) GetFieldById(id string) Field {
 }
 
 func (f *Form) GetFieldsToDisplay() []Field {
+	if f.IsWizard() {
+		return f.GetCurrentPage().GetFieldsToDisplay()
+	}
 	var fieldsToDisplay []Field
 	for _, field := range f.Fields {
 		if field.ShouldDisplay() {
@@ -527,20 +1226,44 @@ func (f *Form) GetFieldsToDisplay() []Field {
 	return fieldsToDisplay
 }
 
+// GetFieldValues returns the values of the top level fields, groups return the values of their fields as JSON.
+// Hidden fields are left out if the hidden field policy is ExcludeHiddenValues.
 func (f *Form) GetFieldValues() map[string]string {
 	fieldValues := make(map[string]string)
 	for _, field := range f.Fields {
+		if f.excludesValue(field) {
+			continue
+		}
 		fieldValues[field.GetId()] = field.GetValue()
 	}
 	return fieldValues
 }
 
+// GetAllFieldValues returns the values of all fields including the ones nested in groups (but not the JSON values of the groups).
+// Hidden fields are left out if the hidden field policy is ExcludeHiddenValues.
+func (f *Form) GetAllFieldValues() map[string]string {
+	fieldValues := f.getAllFieldValues()
+	for _, field := range f.GetAllFields() {
+		if f.excludesValue(field) {
+			delete(fieldValues, field.GetId())
+		}
+	}
+	return fieldValues
+}
+
+// SetOnChangeCallback sets the callback that is called after every change of a value (see SetCallbackDispatcher)
 func (f *Form) SetOnChangeCallback(onChange func()) {
+	f.stateMu.Lock()
+	defer f.stateMu.Unlock()
 	f.onChange = onChange
 }
 
 func (f *Form) GetError() error {
-	for _, field := range f.Fields {
+	return getFieldsError(f.getFieldsToValidate())
+}
+
+func getFieldsError(fields []Field) error {
+	for _, field := range fields {
 		if !field.IsValid() {
 			return &CustomError{Message: field.GetId() + " is not valid (" + field.GetError().Error() + ")"}
 		}
@@ -550,23 +1273,20 @@ func (f *Form) GetError() error {
 
 // Defining the form builder functions
 
-// NewForm creates a new form with the given fields
+// NewForm creates a new form with the given fields.
+// It panics if computed fields depend on each other in a cycle, NewCheckedForm returns an error instead.
 func NewForm(fields ...Field) *Form {
-	form := &Form{Fields: fields, onChange: func() {}}
+	computedFields, err := computedFieldOrder(fields)
+	if err != nil {
+		panic(err)
+	}
+	form := &Form{Fields: fields, onChange: func() {}, dispatch: dispatchDirectly, history: newHistory(), computedFields: computedFields, dependents: indexDependents(fields)}
 	for _, field := range fields {
-		switch v := field.(type) {
-		case *FieldBaseType:
-			v.form = form
-		case *TextField:
-			v.FieldBaseType.form = form
-		case *NumberField:
-			v.TextField.FieldBaseType.form = form
-		case *MultipleChoiceField:
-			v.TextField.FieldBaseType.form = form
-		case *FieldGroup:
-			v.FieldBaseType.form = form
-		}
+		form.attachField(field)
 	}
+	form.applyDynamicDefaults()
+	form.recomputeAll()
+	form.pristine = form.getAllFieldValues()
 	return form
 }
 
@@ -577,20 +1297,25 @@ func NewFieldGroup(id string, displayConditions []DisplayCondition, validators [
 
 // NewTextField creates a new text field with the given parameters
 func NewTextField(id string, displayConditions []DisplayCondition, validators []Validator, placeholder string, prompt string, defaultValue string) *TextField {
-	return &TextField{FieldBaseType: &FieldBaseType{Id: id, DisplayConditions: displayConditions, Validators: validators, Value: defaultValue}, Placeholder: placeholder, Prompt: prompt}
+	return &TextField{FieldBaseType: &FieldBaseType{Id: id, DisplayConditions: displayConditions, Validators: validators, Value: defaultValue, Default: defaultValue}, Placeholder: placeholder, Prompt: prompt}
 }
 
 // NewNumberField creates a new number field with the given parameters
 func NewNumberField(id string, displayConditions []DisplayCondition, validators []Validator, placeholder string, prompt string, defaultValue int) *NumberField {
-	return &NumberField{TextField: &TextField{FieldBaseType: &FieldBaseType{Id: id, DisplayConditions: displayConditions, Validators: validators, Value: strconv.Itoa(defaultValue)}, Placeholder: placeholder, Prompt: prompt}}
+	return &NumberField{TextField: &TextField{FieldBaseType: &FieldBaseType{Id: id, DisplayConditions: displayConditions, Validators: validators, Value: strconv.Itoa(defaultValue), Default: strconv.Itoa(defaultValue)}, Placeholder: placeholder, Prompt: prompt}}
 }
 
 // NewMultipleChoiceField creates a new multiple choice field with the given parameters
 func NewMultipleChoiceField(id string, displayConditions []DisplayCondition, validators []Validator, placeholder string, prompt string, options map[string]Option, defaultValue string) *MultipleChoiceField {
-	return &MultipleChoiceField{TextField: &TextField{FieldBaseType: &FieldBaseType{Id: id, DisplayConditions: displayConditions, Validators: validators, Value: defaultValue}, Placeholder: placeholder, Prompt: prompt}, Options: options}
+	return &MultipleChoiceField{TextField: &TextField{FieldBaseType: &FieldBaseType{Id: id, DisplayConditions: displayConditions, Validators: validators, Value: defaultValue, Default: defaultValue}, Placeholder: placeholder, Prompt: prompt}, Options: options}
 }
 
 // NewMessage creates a new message with the given parameters
 func NewMessage(id string, displayConditions []DisplayCondition, message string) *Message {
 	return &Message{FieldBaseType: &FieldBaseType{Id: id, DisplayConditions: displayConditions, Validators: []Validator{}, Value: message}}
 }
+
+// NewComputedField creates a new computed field whose value is derived from the fields it depends on
+func NewComputedField(id string, displayConditions []DisplayCondition, validators []Validator, prompt string, dependsOn []string, compute func(values map[string]string) string, readOnly bool) *ComputedField {
+	return &ComputedField{TextField: &TextField{FieldBaseType: &FieldBaseType{Id: id, DisplayConditions: displayConditions, Validators: validators, ReadOnly: readOnly}, Prompt: prompt}, DependsOn: dependsOn, Compute: compute}
+}
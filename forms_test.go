@@ -0,0 +1,225 @@
+package go_forms
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRequiredFields(t *testing.T) {
+	tests := []struct {
+		name string
+		// field configures the field with the id "name", other is the value of the field "software"
+		field func(field *TextField)
+		other string
+		value string
+		// code is the code of the error of name or "" if it is valid
+		code     string
+		required bool
+	}{
+		{
+			name:     "required without value",
+			field:    func(field *TextField) { field.Required = true },
+			code:     ErrorCodeRequired,
+			required: true,
+		},
+		{
+			name:     "required with value",
+			field:    func(field *TextField) { field.Required = true },
+			value:    "lobby",
+			required: true,
+		},
+		{
+			name:  "optional without value",
+			field: func(field *TextField) {},
+		},
+		{
+			name: "required if met",
+			field: func(field *TextField) {
+				field.RequiredIf = []DisplayCondition{&HasValueDisplayCondition{FieldId: "software", Value: "paper"}}
+			},
+			other:    "paper",
+			code:     ErrorCodeRequired,
+			required: true,
+		},
+		{
+			name: "required if not met",
+			field: func(field *TextField) {
+				field.RequiredIf = []DisplayCondition{&HasValueDisplayCondition{FieldId: "software", Value: "paper"}}
+			},
+			other: "vanilla",
+		},
+		{
+			name:     "not empty validator marks the field as required",
+			field:    func(field *TextField) { field.Validators = []Validator{&NotEmptyValidator{}} },
+			code:     ErrorCodeNotEmpty,
+			required: true,
+		},
+		{
+			name:  "required if validator with the other field set",
+			field: func(field *TextField) { field.Validators = []Validator{&RequiredIfValidator{FieldId: "software"}} },
+			other: "paper",
+			code:  ErrorCodeRequiredIf,
+		},
+		{
+			name:  "required if validator with the other field empty",
+			field: func(field *TextField) { field.Validators = []Validator{&RequiredIfValidator{FieldId: "software"}} },
+		},
+		{
+			name: "required unless validator with the other field set",
+			field: func(field *TextField) {
+				field.Validators = []Validator{&RequiredUnlessValidator{FieldId: "software", Value: "vanilla"}}
+			},
+			other: "vanilla",
+		},
+		{
+			name: "required unless validator with another value",
+			field: func(field *TextField) {
+				field.Validators = []Validator{&RequiredUnlessValidator{FieldId: "software", Value: "vanilla"}}
+			},
+			other: "paper",
+			code:  ErrorCodeRequiredUnless,
+		},
+		{
+			name: "validators skip empty optional values",
+			field: func(field *TextField) {
+				field.Validators = []Validator{&MinLengthValidator{MinLength: 3}, &EmailValidator{}}
+			},
+		},
+		{
+			name: "validators run on required values",
+			field: func(field *TextField) {
+				field.Required = true
+				field.Validators = []Validator{&MinLengthValidator{MinLength: 3}}
+			},
+			value:    "mc",
+			code:     ErrorCodeMinLength,
+			required: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			name := NewTextField("name", nil, nil, "", "Name", test.value)
+			test.field(name)
+			NewForm(NewTextField("software", nil, nil, "", "Software", test.other), name)
+			if name.IsRequired() != test.required {
+				t.Errorf("name is required: %v, expected %v", name.IsRequired(), test.required)
+			}
+			valid := name.IsValid()
+			if valid != (test.code == "") {
+				t.Fatalf("name is valid: %v, expected %v (error: %v)", valid, test.code == "", name.GetError())
+			}
+			var customErr *CustomError
+			if !valid && (!errors.As(name.GetError(), &customErr) || customErr.Code != test.code) {
+				t.Errorf("error is %v, expected the code %q", name.GetError(), test.code)
+			}
+		})
+	}
+}
+
+func TestRequiredIfFollowsTheOtherField(t *testing.T) {
+	software := NewTextField("software", nil, nil, "", "Software", "vanilla")
+	name := NewTextField("name", nil, nil, "", "Name", "")
+	name.RequiredIf = []DisplayCondition{&HasValueDisplayCondition{FieldId: "software", Value: "paper"}}
+	NewForm(software, name)
+	for _, step := range []struct {
+		software string
+		required bool
+	}{{"paper", true}, {"vanilla", false}, {"paper", true}} {
+		software.SetValue(step.software)
+		if name.IsRequired() != step.required || name.IsValid() == step.required {
+			t.Errorf("with software %s name is required: %v and valid: %v, expected required: %v", step.software, name.IsRequired(), name.IsValid(), step.required)
+		}
+	}
+}
+
+func TestTerminalMarksRequiredFields(t *testing.T) {
+	name := NewTextField("name", nil, nil, "", "Name", "")
+	name.Required = true
+	motd := NewTextField("motd", nil, nil, "", "Message of the day", "")
+	plugins := NewTextField("plugins", nil, nil, "", "Plugins", "")
+	plugins.RequiredIf = []DisplayCondition{&HasValueDisplayCondition{FieldId: "motd", Value: "plugins"}}
+	var out bytes.Buffer
+	if _, err := FormToTerminal(NewForm(name, motd, plugins), strings.NewReader("\nlobby\nplugins\nworldedit\n"), &out); err != nil {
+		t.Fatalf("FormToTerminal returned %v (output: %s)", err, out.String())
+	}
+	for _, expected := range []string{"Name *: Error: Field is required", "Message of the day: ", "Plugins *: "} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("output does not contain %q: %s", expected, out.String())
+		}
+	}
+}
+
+func TestLockedFields(t *testing.T) {
+	tests := []struct {
+		name string
+		// lock makes the field with the id "id" read-only or disabled
+		lock func(field *TextField)
+		// valid is the validity of the invalid value of the field, output is printed instead of asking for the value
+		valid  bool
+		output string
+	}{
+		{
+			name:   "read-only",
+			lock:   func(field *TextField) { field.ReadOnly = true },
+			valid:  false,
+			output: "Id: 4x",
+		},
+		{
+			name: "disabled",
+			lock: func(field *TextField) {
+				field.DisableConditions = []DisplayCondition{&HasValueDisplayCondition{FieldId: "mode", Value: "simple"}}
+			},
+			valid:  true,
+			output: "Id: 4x (disabled)",
+		},
+		{
+			name: "disabled and validated",
+			lock: func(field *TextField) {
+				field.DisableConditions = []DisplayCondition{&HasValueDisplayCondition{FieldId: "mode", Value: "simple"}}
+				field.DisabledValidation = ValidateDisabled
+			},
+			valid:  false,
+			output: "Id: 4x (disabled)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id := NewTextField("id", nil, []Validator{&CharacterClassValidator{Allowed: CharacterClassDigit}}, "", "Id", "4x")
+			test.lock(id)
+			form := NewForm(NewTextField("mode", nil, nil, "", "Mode", "simple"), id)
+			if id.IsEditable() {
+				t.Errorf("id is editable")
+			}
+			if id.IsValid() != test.valid {
+				t.Errorf("id is valid: %v, expected %v", id.IsValid(), test.valid)
+			}
+			// The terminal only asks for mode, the answer for id is left unread
+			var out bytes.Buffer
+			values, err := FormToTerminal(form, strings.NewReader("\n42\n"), &out)
+			if test.valid && err != nil {
+				t.Fatalf("FormToTerminal returned %v (output: %s)", err, out.String())
+			}
+			if !strings.Contains(out.String(), test.output) || strings.Contains(out.String(), "Id [4x]: ") {
+				t.Errorf("output does not show %q instead of asking for id: %s", test.output, out.String())
+			}
+			if test.valid && values["id"] != "4x" {
+				t.Errorf("id is %q after the terminal, expected 4x", values["id"])
+			}
+		})
+	}
+}
+
+func TestPrefillSkipsReadOnlyFields(t *testing.T) {
+	id := NewTextField("id", nil, nil, "", "Id", "42")
+	id.ReadOnly = true
+	form := NewForm(NewTextField("name", nil, nil, "", "Name", ""), id)
+	provided := form.Prefill(MapSource{"name": "lobby", "id": "43"})
+	if values := form.GetAllFieldValues(); values["name"] != "lobby" || values["id"] != "42" {
+		t.Errorf("values are %v, expected name lobby and the read-only id 42", values)
+	}
+	if len(provided) != 1 || provided[0] != "name" {
+		t.Errorf("provided fields are %q, expected name", provided)
+	}
+}
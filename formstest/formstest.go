@@ -0,0 +1,423 @@
+// Package formstest renders forms with the Fyne test driver so that their UI behaviour can be tested without a display
+package formstest
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	forms "github.com/CUBUS-mc/go-forms"
+)
+
+// Harness is a form rendered with FormToFyneForm into a test window
+type Harness struct {
+	t      testing.TB
+	form   *forms.Form
+	window fyne.Window
+	box    *fyne.Container
+	widget *forms.FyneForm
+
+	mu        sync.Mutex
+	submitted map[string]string
+	submits   int
+	cancels   int
+}
+
+// Render renders the form into a new test window, the window is closed when the test ends
+func Render(t testing.TB, form *forms.Form) *Harness {
+	t.Helper()
+	app := test.NewApp()
+	h := &Harness{t: t, form: form, box: container.New(layout.NewVBoxLayout())}
+	h.window = app.NewWindow("Form")
+	h.window.SetContent(h.box)
+	h.window.Resize(fyne.NewSize(700, 400))
+	h.widget = forms.FormToFyneForm(form, h.box, h.window, h.onSubmit, h.onCancel)
+	t.Cleanup(func() {
+		h.window.Close()
+		app.Quit()
+	})
+	return h
+}
+
+// sync waits until the widgets show the last change, the widget updates them asynchronously (see forms.FyneForm.WaitForUpdates)
+func (h *Harness) sync() {
+	h.widget.WaitForUpdates()
+}
+
+// act acts like the user on the goroutine updating the widgets and waits until the widgets show the result (see forms.FyneForm.Update)
+func (h *Harness) act(action func()) {
+	h.widget.Update(action)
+	h.sync()
+}
+
+func (h *Harness) onSubmit(values map[string]string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.submitted = values
+	h.submits++
+}
+
+func (h *Harness) onCancel() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cancels++
+}
+
+func (h *Harness) Form() *forms.Form {
+	return h.form
+}
+
+func (h *Harness) Window() fyne.Window {
+	return h.window
+}
+
+// Widget returns the widget of the field with the given id, the test fails if the field is not displayed
+func (h *Harness) Widget(id string) fyne.CanvasObject {
+	h.t.Helper()
+	h.sync()
+	object := forms.FyneFieldWidget(h.box, id)
+	if object == nil {
+		h.t.Fatalf("field %q is not displayed", id)
+	}
+	return object
+}
+
+func (h *Harness) entry(id string) *forms.FyneEntry {
+	h.t.Helper()
+	entry, ok := h.Widget(id).(*forms.FyneEntry)
+	if !ok {
+		h.t.Fatalf("field %q is not rendered as a text entry", id)
+	}
+	return entry
+}
+
+// Type replaces the text of the field with the given id by typing the text key by key
+func (h *Harness) Type(id string, text string) {
+	h.t.Helper()
+	entry := h.entry(id)
+	if entry.Disabled() {
+		h.t.Fatalf("field %q is disabled", id)
+	}
+	h.act(func() {
+		entry.SetText("")
+	})
+	// The widgets are refreshed after every key before the next one is typed, like for a user typing at a normal speed
+	for _, char := range text {
+		h.act(func() {
+			test.Type(entry, string(char))
+		})
+	}
+}
+
+// Blur leaves the field with the given id like the user moving the focus to another widget, which marks the field as touched
+func (h *Harness) Blur(id string) {
+	h.t.Helper()
+	entry := h.entry(id)
+	h.act(func() {
+		h.window.Canvas().Focus(entry)
+		h.window.Canvas().Unfocus()
+	})
+}
+
+// Undo presses Ctrl+Z in the entry of the field with the given id, which undoes the last change of the form
+func (h *Harness) Undo(id string) {
+	h.t.Helper()
+	entry := h.entry(id)
+	h.act(func() {
+		entry.TypedShortcut(&fyne.ShortcutUndo{})
+	})
+}
+
+// Redo presses Ctrl+Y in the entry of the field with the given id, which redoes the last undone change of the form
+func (h *Harness) Redo(id string) {
+	h.t.Helper()
+	entry := h.entry(id)
+	h.act(func() {
+		entry.TypedShortcut(&fyne.ShortcutRedo{})
+	})
+}
+
+// SetText replaces the text of the field with the given id at once
+func (h *Harness) SetText(id string, text string) {
+	h.t.Helper()
+	entry := h.entry(id)
+	h.act(func() {
+		entry.SetText(text)
+	})
+}
+
+// Select selects the option with the given key of the multiple choice field with the given id
+func (h *Harness) Select(id string, key string) {
+	h.t.Helper()
+	selectWidget, ok := h.Widget(id).(*widget.Select)
+	if !ok {
+		h.t.Fatalf("field %q is not rendered as a select", id)
+	}
+	field, ok := h.findField(id).(*forms.MultipleChoiceField)
+	if !ok {
+		h.t.Fatalf("field %q is not a multiple choice field", id)
+	}
+	option, ok := field.GetOptions()[key]
+	if !ok {
+		h.t.Fatalf("field %q has no option %q", id, key)
+	}
+	h.act(func() {
+		selectWidget.SetSelected(option.Label)
+	})
+}
+
+func (h *Harness) findField(id string) forms.Field {
+	for _, field := range h.form.GetAllFields() {
+		if field.GetId() == id {
+			return field
+		}
+	}
+	return nil
+}
+
+// Submit taps the submit button (or the next button of a wizard page), the test fails if the button is disabled
+func (h *Harness) Submit() {
+	h.t.Helper()
+	h.tap(isSubmitButton, "submit")
+}
+
+// SubmitEnabled returns false if the submit button is disabled because a field is invalid
+func (h *Harness) SubmitEnabled() bool {
+	h.t.Helper()
+	button := h.findButton(isSubmitButton)
+	if button == nil {
+		h.t.Fatalf("button %q is not displayed", "submit")
+	}
+	return !button.Disabled()
+}
+
+func isSubmitButton(button *widget.Button) bool {
+	return button.Importance == widget.HighImportance
+}
+
+// Cancel taps the cancel button, a changed form asks for a confirmation first (see Tap)
+func (h *Harness) Cancel() {
+	h.t.Helper()
+	h.Tap("Cancel")
+}
+
+// Back taps the back button of a wizard
+func (h *Harness) Back() {
+	h.t.Helper()
+	h.Tap("Back")
+}
+
+// Tap taps the button with the given text in the form or in a dialog (e.g. "Discard" to confirm cancelling a changed form)
+func (h *Harness) Tap(text string) {
+	h.t.Helper()
+	h.tap(func(button *widget.Button) bool {
+		return button.Text == text
+	}, text)
+}
+
+func (h *Harness) tap(matches func(button *widget.Button) bool, name string) {
+	h.t.Helper()
+	button := h.findButton(matches)
+	if button == nil {
+		h.t.Fatalf("button %q is not displayed", name)
+	}
+	if button.Disabled() {
+		h.t.Fatalf("button %q is disabled", name)
+	}
+	h.act(func() {
+		test.Tap(button)
+	})
+}
+
+// findButton searches the button in the window content and then in the dialogs, the topmost dialog first
+func (h *Harness) findButton(matches func(button *widget.Button) bool) *widget.Button {
+	h.sync()
+	objects := visibleObjects(h.window.Content())
+	overlays := h.window.Canvas().Overlays().List()
+	for index := len(overlays) - 1; index >= 0; index-- {
+		objects = append(objects, visibleObjects(overlays[index])...)
+	}
+	for _, object := range objects {
+		if button, ok := object.(*widget.Button); ok && matches(button) {
+			return button
+		}
+	}
+	return nil
+}
+
+// visibleObjects returns the object and all visible objects below it. The renderers of the widgets are taken from the cache because
+// test.LaidOutObjects creates new renderers, which breaks widgets like widget.Form that keep references to their rendered objects.
+func visibleObjects(object fyne.CanvasObject) []fyne.CanvasObject {
+	if object == nil || !object.Visible() {
+		return nil
+	}
+	objects := []fyne.CanvasObject{object}
+	var children []fyne.CanvasObject
+	switch object := object.(type) {
+	case *fyne.Container:
+		children = object.Objects
+	case fyne.Widget:
+		children = test.WidgetRenderer(object).Objects()
+	}
+	for _, child := range children {
+		objects = append(objects, visibleObjects(child)...)
+	}
+	return objects
+}
+
+// VisibleFields returns the ids of the fields that are displayed (including messages and group headings)
+func (h *Harness) VisibleFields() []string {
+	h.sync()
+	var ids []string
+	for _, field := range h.form.GetAllFields() {
+		if forms.FyneFieldWidget(h.box, field.GetId()) != nil {
+			ids = append(ids, field.GetId())
+		}
+	}
+	return ids
+}
+
+func (h *Harness) AssertVisible(ids ...string) {
+	h.t.Helper()
+	h.sync()
+	for _, id := range ids {
+		if forms.FyneFieldWidget(h.box, id) == nil {
+			h.t.Errorf("field %q is not displayed", id)
+		}
+	}
+}
+
+func (h *Harness) AssertHidden(ids ...string) {
+	h.t.Helper()
+	h.sync()
+	for _, id := range ids {
+		if forms.FyneFieldWidget(h.box, id) != nil {
+			h.t.Errorf("field %q is displayed", id)
+		}
+	}
+}
+
+// IsDisabled returns true if the widget of the field with the given id is disabled (read-only or disabled fields)
+func (h *Harness) IsDisabled(id string) bool {
+	h.t.Helper()
+	disableable, ok := h.Widget(id).(fyne.Disableable)
+	return ok && disableable.Disabled()
+}
+
+// AssertDisabled fails the test if the widgets of the given fields are enabled
+func (h *Harness) AssertDisabled(ids ...string) {
+	h.t.Helper()
+	for _, id := range ids {
+		if !h.IsDisabled(id) {
+			h.t.Errorf("field %q is enabled", id)
+		}
+	}
+}
+
+// AssertEnabled fails the test if the widgets of the given fields are disabled
+func (h *Harness) AssertEnabled(ids ...string) {
+	h.t.Helper()
+	for _, id := range ids {
+		if h.IsDisabled(id) {
+			h.t.Errorf("field %q is disabled", id)
+		}
+	}
+}
+
+// FieldError returns the error shown under the field with the given id or nil (see FyneErrorPresentation)
+func (h *Harness) FieldError(id string) error {
+	h.t.Helper()
+	h.Widget(id)
+	return h.widget.FieldError(id)
+}
+
+// FyneForm returns the rendered widget, e.g. to change its error presentation
+func (h *Harness) FyneForm() *forms.FyneForm {
+	return h.widget
+}
+
+// DialogMessages returns the texts of the dialogs shown in the window (e.g. the error shown after an invalid submit)
+func (h *Harness) DialogMessages() []string {
+	h.sync()
+	var messages []string
+	for _, overlay := range h.window.Canvas().Overlays().List() {
+		for _, object := range visibleObjects(overlay) {
+			if label, ok := object.(*widget.Label); ok && label.Text != "" {
+				messages = append(messages, label.Text)
+			}
+		}
+	}
+	return messages
+}
+
+// AssertDialogMessage fails the test if no dialog shows a message containing the given text
+func (h *Harness) AssertDialogMessage(text string) {
+	h.t.Helper()
+	messages := h.DialogMessages()
+	if !slices.ContainsFunc(messages, func(message string) bool {
+		return strings.Contains(message, text)
+	}) {
+		h.t.Errorf("no dialog shows %q (dialog messages: %q)", text, messages)
+	}
+}
+
+// Submitted returns the values delivered to onSubmit by the last submit and if the form was submitted at all
+func (h *Harness) Submitted() (map[string]string, bool) {
+	h.sync()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.submitted, h.submits > 0
+}
+
+// Cancelled returns true if the cancel button was tapped
+func (h *Harness) Cancelled() bool {
+	h.sync()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.cancels > 0
+}
+
+// AssertSubmitted fails the test if the form was not submitted with the given values
+func (h *Harness) AssertSubmitted(values map[string]string) {
+	h.t.Helper()
+	submitted, ok := h.Submitted()
+	if !ok {
+		h.t.Errorf("form was not submitted")
+		return
+	}
+	for id, value := range values {
+		if submittedValue, ok := submitted[id]; !ok || submittedValue != value {
+			h.t.Errorf("submitted value of %q is %q, expected %q", id, submittedValue, value)
+		}
+	}
+	for id := range submitted {
+		if _, ok := values[id]; !ok {
+			h.t.Errorf("unexpected submitted value for %q: %q", id, submitted[id])
+		}
+	}
+}
+
+func (h *Harness) AssertNotSubmitted() {
+	h.t.Helper()
+	if _, ok := h.Submitted(); ok {
+		h.t.Errorf("form was submitted")
+	}
+}
+
+// WaitForSubmit waits until the form was submitted, which is needed for forms with asynchronous validators
+func (h *Harness) WaitForSubmit(timeout time.Duration) (map[string]string, bool) {
+	deadline := time.Now().Add(timeout)
+	for {
+		if values, ok := h.Submitted(); ok || time.Now().After(deadline) {
+			return values, ok
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,311 @@
+package formstest_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	forms "github.com/CUBUS-mc/go-forms"
+	"github.com/CUBUS-mc/go-forms/formstest"
+)
+
+func TestNormalizedEntryKeepsTypedText(t *testing.T) {
+	name := forms.NewTextField("name", nil, nil, "", "Name", "")
+	name.Normalizers = []forms.Normalizer{&forms.TrimNormalizer{}}
+	h := formstest.Render(t, forms.NewForm(name))
+	h.Type("name", "John Doe ")
+	entry := h.Widget("name").(*forms.FyneEntry)
+	if entry.Text != "John Doe " {
+		t.Errorf("entry shows %q while typing, expected %q", entry.Text, "John Doe ")
+	}
+	if name.GetValue() != "John Doe" {
+		t.Errorf("value is %q, expected %q", name.GetValue(), "John Doe")
+	}
+	h.Blur("name")
+	if entry := h.Widget("name").(*forms.FyneEntry); entry.Text != "John Doe" {
+		t.Errorf("entry shows %q after leaving it, expected the normalized value %q", entry.Text, "John Doe")
+	}
+}
+
+func TestUndoShortcutsAreScopedToTheForm(t *testing.T) {
+	first := forms.NewForm(forms.NewTextField("name", nil, nil, "", "Name", ""))
+	second := forms.NewForm(forms.NewTextField("motd", nil, nil, "", "Message of the day", ""))
+	h := formstest.Render(t, first)
+	other := forms.NewFyneForm(second, h.Window())
+	h.Window().Content().(*fyne.Container).Add(other)
+	h.SetText("name", "lobby")
+	other.WaitForUpdates()
+	other.Update(func() {
+		other.FieldWidget("motd").(*forms.FyneEntry).SetText("Welcome")
+	})
+	other.WaitForUpdates()
+
+	h.Undo("name")
+	if value := first.GetAllFieldValues()["name"]; value != "" {
+		t.Errorf("name is %q after undo, expected it to be empty", value)
+	}
+	if value := second.GetAllFieldValues()["motd"]; value != "Welcome" {
+		t.Errorf("undo in the first form changed the second form (motd: %q)", value)
+	}
+	if entry := h.Widget("name").(*forms.FyneEntry); entry.Text != "" {
+		t.Errorf("entry shows %q after undo, expected it to be empty", entry.Text)
+	}
+	h.Redo("name")
+	if value := first.GetAllFieldValues()["name"]; value != "lobby" {
+		t.Errorf("name is %q after redo, expected %q", value, "lobby")
+	}
+}
+
+func TestSubmitWaitsForAsyncValidationOnce(t *testing.T) {
+	release := make(chan struct{})
+	name := forms.NewTextField("name", nil, nil, "", "Name", "lobby")
+	name.AsyncValidators = []forms.AsyncValidator{&forms.CustomAsyncValidator{Validator: func(ctx context.Context, _ any) error {
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}}}
+	h := formstest.Render(t, forms.NewForm(name))
+	var mu sync.Mutex
+	submits := 0
+	onSubmit := h.FyneForm().OnSubmit
+	h.FyneForm().OnSubmit = func(values map[string]string) {
+		mu.Lock()
+		submits++
+		mu.Unlock()
+		onSubmit(values)
+	}
+	h.Submit()
+	if h.SubmitEnabled() {
+		t.Errorf("submit button is enabled while the validation is pending")
+	}
+	h.FyneForm().Submit()
+	close(release)
+	if _, ok := h.WaitForSubmit(5 * time.Second); !ok {
+		t.Fatal("form was not submitted after the validation finished")
+	}
+	h.FyneForm().WaitForUpdates()
+	mu.Lock()
+	defer mu.Unlock()
+	if submits != 1 {
+		t.Errorf("form was submitted %d times, expected once", submits)
+	}
+	if !h.SubmitEnabled() {
+		t.Errorf("submit button is disabled after the submit")
+	}
+}
+
+func TestResetRestoresHiddenValues(t *testing.T) {
+	mode := forms.NewMultipleChoiceField("mode", nil, nil, "", "Mode", map[string]forms.Option{"simple": {Label: "Simple"}, "advanced": {Label: "Advanced"}}, "simple")
+	extra := forms.NewTextField("extra", []forms.DisplayCondition{&forms.HasValueDisplayCondition{FieldId: "mode", Value: "advanced"}}, nil, "", "Extra", "initial")
+	form := forms.NewForm(mode, extra)
+	form.SetHiddenFieldPolicy(forms.ExcludeHiddenValues)
+	h := formstest.Render(t, form)
+	h.Select("mode", "advanced")
+	h.SetText("extra", "changed")
+	if err := h.FyneForm().Reset(); err != nil {
+		t.Fatalf("Reset returned %v", err)
+	}
+	h.FyneForm().WaitForUpdates()
+	if mode.GetValue() != "simple" || extra.GetValue() != "initial" {
+		t.Errorf("values after reset are mode %q and extra %q, expected simple and initial", mode.GetValue(), extra.GetValue())
+	}
+}
+
+func TestFormToFyneFormReplacesTheContent(t *testing.T) {
+	h := formstest.Render(t, forms.NewForm(forms.NewTextField("name", nil, nil, "", "Name", "")))
+	box := h.Window().Content().(*fyne.Container)
+	forms.FormToFyneForm(forms.NewForm(forms.NewTextField("motd", nil, nil, "", "Message of the day", "")), box, h.Window(), nil, nil)
+	if len(box.Objects) != 1 {
+		t.Errorf("container has %d objects, expected only the new form", len(box.Objects))
+	}
+}
+
+func TestDisabledSubmitKeepsTheErrorsOfUntouchedFields(t *testing.T) {
+	name := forms.NewTextField("name", nil, nil, "", "Name", "")
+	name.Required = true
+	h := formstest.Render(t, forms.NewForm(name, forms.NewTextField("motd", nil, nil, "", "Message of the day", "")))
+	h.FyneForm().SetErrorPresentation(forms.FyneErrorPresentation{Inline: true, DisableSubmit: true})
+	h.SetText("motd", "Welcome")
+	if h.SubmitEnabled() {
+		t.Errorf("submit button is enabled although name is required")
+	}
+	if err := name.GetError(); err != nil {
+		t.Errorf("untouched field has the error %v", err)
+	}
+	if err := h.FieldError("name"); err != nil {
+		t.Errorf("untouched field shows the error %v", err)
+	}
+}
+
+func TestSubmitScrollsToTheFirstInvalidField(t *testing.T) {
+	var fields []forms.Field
+	for index := range 20 {
+		fields = append(fields, forms.NewTextField(fmt.Sprintf("field%d", index), nil, nil, "", fmt.Sprintf("Field %d", index), "value"))
+	}
+	last := forms.NewTextField("last", nil, nil, "", "Last", "")
+	last.Required = true
+	h := formstest.Render(t, forms.NewForm(append(fields, last)...))
+	h.FyneForm().SetErrorPresentation(forms.FyneErrorPresentation{Inline: true, FocusFirstInvalid: true})
+	scroll := container.NewVScroll(h.Window().Content())
+	h.Window().SetContent(scroll)
+	h.Window().Resize(fyne.NewSize(700, 400))
+	h.Submit()
+	if scroll.Offset.Y == 0 {
+		t.Errorf("form was not scrolled to the invalid field")
+	}
+	if focused := h.Window().Canvas().Focused(); focused != h.Widget("last").(fyne.Focusable) {
+		t.Errorf("focused widget is %v, expected the entry of last", focused)
+	}
+}
+
+func newServerForm() *forms.Form {
+	name := forms.NewTextField("name", nil, nil, "", "Name", "")
+	name.Required = true
+	software := forms.NewMultipleChoiceField("software", nil, nil, "", "Software", map[string]forms.Option{"paper": {Label: "Paper"}, "vanilla": {Label: "Vanilla"}}, "vanilla")
+	ram := forms.NewNumberField("ram", []forms.DisplayCondition{&forms.HasValueDisplayCondition{FieldId: "software", Value: "paper"}}, nil, "", "RAM", 2048)
+	id := forms.NewTextField("id", nil, nil, "", "Id", "42")
+	id.ReadOnly = true
+	return forms.NewForm(name, software, ram, id)
+}
+
+func TestHarnessFields(t *testing.T) {
+	h := formstest.Render(t, newServerForm())
+	h.AssertVisible("name", "software", "id")
+	h.AssertHidden("ram")
+	h.AssertDisabled("id")
+	h.AssertEnabled("name", "software")
+	h.Select("software", "paper")
+	h.AssertVisible("ram")
+	if visible := h.VisibleFields(); fmt.Sprint(visible) != "[name software ram id]" {
+		t.Errorf("visible fields are %v, expected [name software ram id]", visible)
+	}
+	h.Type("name", "lobby")
+	if entry := h.Widget("name").(*forms.FyneEntry); entry.Text != "lobby" {
+		t.Errorf("entry shows %q after typing, expected %q", entry.Text, "lobby")
+	}
+	if value := h.Form().GetAllFieldValues()["name"]; value != "lobby" {
+		t.Errorf("name is %q after typing, expected %q", value, "lobby")
+	}
+}
+
+func TestHarnessSubmit(t *testing.T) {
+	h := formstest.Render(t, newServerForm())
+	h.Submit()
+	h.AssertNotSubmitted()
+	h.AssertDialogMessage("Field is required")
+	if err := h.FieldError("name"); err == nil {
+		t.Errorf("no error is shown under name after the invalid submit")
+	}
+	h.Tap("OK")
+	if messages := h.DialogMessages(); len(messages) != 0 {
+		t.Errorf("dialog messages after closing the dialog are %q", messages)
+	}
+	h.SetText("name", "lobby")
+	if err := h.FieldError("name"); err != nil {
+		t.Errorf("error %v is shown under the valid name", err)
+	}
+	h.Submit()
+	h.AssertSubmitted(map[string]string{"name": "lobby", "software": "vanilla", "ram": "2048", "id": "42"})
+}
+
+func TestHarnessWizard(t *testing.T) {
+	h := formstest.Render(t, forms.NewWizard(
+		forms.NewWizardPage("general", "General", nil, forms.NewTextField("name", nil, nil, "", "Name", "lobby")),
+		forms.NewWizardPage("network", "Network", nil, forms.NewNumberField("port", nil, nil, "", "Port", 25565)),
+	))
+	h.AssertVisible("name")
+	h.AssertHidden("port")
+	h.Tap("Next")
+	h.AssertNotSubmitted()
+	h.AssertVisible("port")
+	h.AssertHidden("name")
+	h.Back()
+	h.AssertVisible("name")
+	h.Submit()
+	h.SetText("port", "25566")
+	h.Submit()
+	h.AssertSubmitted(map[string]string{"name": "lobby", "port": "25566"})
+}
+
+func TestHarnessCancel(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  bool
+		discard bool
+		// cancelled is true if onCancel is called
+		cancelled bool
+	}{
+		{name: "pristine form", cancelled: true},
+		{name: "discard the changes", change: true, discard: true, cancelled: true},
+		{name: "keep editing", change: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := formstest.Render(t, newServerForm())
+			if test.change {
+				h.SetText("name", "lobby")
+			}
+			h.Cancel()
+			if test.change {
+				h.AssertDialogMessage("The form has unsaved changes.")
+				if test.discard {
+					h.Tap("Discard")
+				} else {
+					h.Tap("Keep editing")
+				}
+			}
+			if h.Cancelled() != test.cancelled {
+				t.Errorf("cancelled is %v, expected %v", h.Cancelled(), test.cancelled)
+			}
+			h.AssertNotSubmitted()
+		})
+	}
+}
+
+func TestGroupLayouts(t *testing.T) {
+	group := func(id string, layout forms.GroupLayout, heading string, fields ...forms.Field) *forms.FieldGroup {
+		group := forms.NewFieldGroup(id, nil, nil, heading, fields...)
+		group.Layout = layout
+		group.Columns = 3
+		return group
+	}
+	h := formstest.Render(t, forms.NewForm(
+		group("server", forms.GroupLayoutCard, "Server", forms.NewTextField("motd", nil, nil, "", "Message of the day", "")),
+		group("world", forms.GroupLayoutTab, "World", forms.NewTextField("seed", nil, nil, "", "Seed", "")),
+		group("players", forms.GroupLayoutTab, "", forms.NewNumberField("slots", nil, nil, "", "Slots", 20)),
+		group("network", forms.GroupLayoutAccordion, "Network", forms.NewNumberField("port", nil, nil, "", "Port", 25565)),
+		group("advanced", forms.GroupLayoutAccordion, "Advanced", forms.NewTextField("flags", nil, nil, "", "Flags", "")),
+		group("resources", forms.GroupLayoutGrid, "Resources", forms.NewNumberField("ram", nil, nil, "", "RAM", 2048), forms.NewNumberField("cpus", nil, nil, "", "CPUs", 2)),
+	))
+	if card, ok := h.Widget("server").(*widget.Card); !ok || card.Title != "Server" {
+		t.Errorf("server is rendered as %T, expected a card titled Server", h.Widget("server"))
+	}
+	// Consecutive tabs and accordion sections share one widget
+	tabs, ok := h.Widget("world").(*container.AppTabs)
+	if !ok || h.Widget("players") != tabs {
+		t.Fatalf("world and players are rendered as %T and %T, expected the same tabs", h.Widget("world"), h.Widget("players"))
+	}
+	if len(tabs.Items) != 2 || tabs.Items[0].Text != "World" || tabs.Items[1].Text != "players" {
+		t.Errorf("tabs are not titled World and players")
+	}
+	accordion, ok := h.Widget("network").(*widget.Accordion)
+	if !ok || h.Widget("advanced") != accordion {
+		t.Fatalf("network and advanced are rendered as %T and %T, expected the same accordion", h.Widget("network"), h.Widget("advanced"))
+	}
+	if len(accordion.Items) != 2 || accordion.Items[0].Title != "Network" || accordion.Items[1].Title != "Advanced" {
+		t.Errorf("accordion sections are not titled Network and Advanced")
+	}
+	if grid, ok := h.Widget("resources").(*fyne.Container); !ok || len(grid.Objects) != 2 {
+		t.Errorf("resources is rendered as %T, expected a grid with a cell per field", h.Widget("resources"))
+	}
+	h.AssertVisible("motd", "seed", "port", "ram", "cpus")
+}
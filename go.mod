@@ -2,7 +2,11 @@ module github.com/CUBUS-mc/go-forms
 
 go 1.23
 
-require fyne.io/fyne/v2 v2.5.2
+require (
+	fyne.io/fyne/v2 v2.5.2
+	github.com/rivo/uniseg v0.4.7
+	golang.org/x/text v0.16.0
+)
 
 require (
 	fyne.io/systray v1.11.0 // indirect
@@ -32,6 +36,5 @@ require (
 	golang.org/x/mobile v0.0.0-20231127183840-76ac6878050a // indirect
 	golang.org/x/net v0.25.0 // indirect
 	golang.org/x/sys v0.20.0 // indirect
-	golang.org/x/text v0.16.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -1,43 +1,376 @@
 package go_forms
 
 import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
-func refreshForm(form *Form, box *fyne.Container, fyneForm *widget.Form) {
-	fyneForm.Items = nil
-	fyneForm.Refresh()
-	fyneForm.Items = fieldsToFyneForm(form.GetFieldsToDisplay(), form, box, fyneForm)
-	fyneForm.Refresh()
-	box.Refresh()
+// fyneRenderer renders a Form into a Fyne container and keeps the rendered widgets up to date.
+// All updates of the widgets are queued and run one after another by the goroutine draining the queue (see fyneUpdates), changes of the
+// form made on any goroutine only queue a refresh. mu guards the state read by the methods of FyneForm.
+type fyneRenderer struct {
+	mu      sync.Mutex
+	updates *fyneUpdates
+	// refreshQueued is set while a refresh is queued, so that several changes are rendered at once
+	refreshQueued atomic.Bool
+	form          *Form
+	box           *fyne.Container
+	window        fyne.Window
+	// formWidget is the FyneForm, the scroll containers around it are scrolled to the first invalid field (see scrollTo)
+	formWidget fyne.CanvasObject
+	fyneForm   *widget.Form
+	widgets    map[string]fyne.CanvasObject
+	// selectedTabs maps the id of the first group of tabs to the selected tab, openGroups contains the open accordion sections
+	selectedTabs   map[string]int
+	openGroups     map[string]bool
+	accordionItems map[string]*widget.AccordionItem
+	// tabs and tabIndexes map the ids of the groups rendered as tab to their tabs and their index in them
+	tabs       map[string]*container.AppTabs
+	tabIndexes map[string]int
+	// summary shows the errors of all invalid fields, submitButton is disabled while the form is invalid if configured
+	summary      *fyne.Container
+	submitButton *widget.Button
+	presentation FyneErrorPresentation
+	// page is the rendered wizard page
+	page *WizardPage
+	// entries contains the entries of the fields, they are reused when the form is rendered again (see entry)
+	entries map[string]*FyneEntry
+	// submitAttempted is true after a failed submit on the current page, the errors of all fields are shown then.
+	// submitting is true while a submit waits for asynchronous validators, the submit button is disabled meanwhile.
+	submitAttempted bool
+	submitting      bool
+	// invalid contains the displayed fields that are invalid in the order of the form, it is computed once per refresh (see validateFields)
+	invalid       []Field
+	shownErrors   map[string]error
+	onSubmit      func(values map[string]string)
+	onCancel      func()
+	onChanged     func()
+	onPageChanged func()
+	// confirmCancel returns true if discarding the changes has to be confirmed before onCancel is called
+	confirmCancel func() bool
+	// removeListeners removes the listeners added to the form
+	removeListeners []func()
 }
 
-func fieldsToFyneForm(fields []Field, form *Form, box *fyne.Container, fyneForm *widget.Form) []*widget.FormItem {
-	var formItems []*widget.FormItem
+// fyneUpdates is the queue of the updates of the widgets of a renderer. The goroutine draining it is started when an update is queued and
+// ends when the queue is empty, so the updates never run concurrently and keep their order.
+type fyneUpdates struct {
+	mu       sync.Mutex
+	queue    []func()
+	draining bool
+	// idle is broadcast when the queue was drained (see wait)
+	idle *sync.Cond
+}
+
+func newFyneUpdates() *fyneUpdates {
+	u := &fyneUpdates{}
+	u.idle = sync.NewCond(&u.mu)
+	return u
+}
+
+// run queues the update
+func (u *fyneUpdates) run(update func()) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.queue = append(u.queue, update)
+	if !u.draining {
+		u.draining = true
+		go u.drain()
+	}
+}
+
+func (u *fyneUpdates) drain() {
+	for {
+		u.mu.Lock()
+		if len(u.queue) == 0 {
+			u.draining = false
+			u.idle.Broadcast()
+			u.mu.Unlock()
+			return
+		}
+		update := u.queue[0]
+		u.queue = u.queue[1:]
+		u.mu.Unlock()
+		update()
+	}
+}
+
+// wait blocks until the queued updates are done, it must not be called by an update
+func (u *fyneUpdates) wait() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for u.draining {
+		u.idle.Wait()
+	}
+}
+
+// queueRefresh queues a refresh of the widgets unless one is queued already. It is called by the listeners of the form on the goroutine
+// that changed it, so it must not lock the renderer (the change may have been made by a widget while the renderer is locked).
+func (r *fyneRenderer) queueRefresh() {
+	if r.refreshQueued.CompareAndSwap(false, true) {
+		r.updates.run(func() {
+			r.refreshQueued.Store(false)
+			r.refresh()
+		})
+	}
+}
+
+// refresh renders the fields again, it runs on the goroutine draining the updates like all functions changing the widgets
+func (r *fyneRenderer) refresh() {
+	r.mu.Lock()
+	if r.fyneForm == nil || r.form == nil {
+		r.mu.Unlock()
+		return
+	}
+	if r.form.GetCurrentPage() != r.page {
+		// The page was changed through the form (e.g. by Reset or ApplyDraft), so the navigation has to be rendered again
+		r.mu.Unlock()
+		r.changePage()
+		r.onChanged()
+		return
+	}
+	r.fyneForm.Items = nil
+	r.fyneForm.Refresh()
+	r.resetWidgets()
+	r.invalid = r.validateFields(r.form.GetFieldsToDisplay())
+	r.fyneForm.Items = r.fieldsToFyneForm(r.form.GetFieldsToDisplay())
+	r.fyneForm.Refresh()
+	r.updateErrorState()
+	r.box.Refresh()
+	r.mu.Unlock()
+	r.onChanged()
+}
+
+// newFormItem creates a form item for the given field, marks required fields with an asterisk, disables the widgets of read-only and
+// disabled fields and shows the help text as hint (or a hint while the field is validated asynchronously).
+// The objects below (e.g. descriptions) and the inline error are shown under the widget.
+func (r *fyneRenderer) newFormItem(text string, field Field, object fyne.CanvasObject, below ...fyne.CanvasObject) *widget.FormItem {
+	r.widgets[field.GetId()] = object
+	base := getFieldBase(field)
+	if base != nil && base.IsRequired() && base.IsEditable() {
+		text += " *"
+	}
+	if disableable, ok := object.(fyne.Disableable); ok && base != nil {
+		// Entries are reused, so they have to be enabled again when the field becomes editable
+		if base.IsEditable() {
+			disableable.Enable()
+		} else {
+			disableable.Disable()
+		}
+	}
+	if err := r.inlineError(field); err != nil {
+		r.shownErrors[field.GetId()] = err
+		errorLabel := widget.NewLabel(err.Error())
+		errorLabel.Importance = widget.DangerImportance
+		errorLabel.Wrapping = fyne.TextWrapWord
+		below = append(below, errorLabel)
+	}
+	item := widget.NewFormItem(text, object)
+	if len(below) > 0 {
+		item.Widget = container.NewVBox(append([]fyne.CanvasObject{object}, below...)...)
+	}
+	if base != nil {
+		item.HintText = base.GetHelpText()
+		if base.IsPending() {
+			item.HintText = "Validating..."
+		}
+	}
+	return item
+}
+
+// inlineError returns the error to show under the field or nil if the field is valid or its error should not be shown yet
+func (r *fyneRenderer) inlineError(field Field) error {
+	if _, ok := field.(*Message); ok || !r.presentation.Inline {
+		return nil
+	}
+	if !r.submitAttempted && !r.form.ShouldShowError(field.GetId()) {
+		return nil
+	}
+	if !slices.Contains(r.invalid, field) || errors.Is(field.GetError(), ErrValidationPending) {
+		return nil
+	}
+	return field.GetError()
+}
+
+// FyneEntry is the entry rendered for text, number and computed fields, OnFocusLost is called when the user leaves it.
+// OnUndo and OnRedo replace the undo and redo of the text of the entry, the renderer uses them to undo and redo the changes of the form.
+type FyneEntry struct {
+	widget.Entry
+	OnFocusLost func()
+	OnUndo      func()
+	OnRedo      func()
+
+	// typed is the normalized text the user typed last, the renderer only replaces the text if the value of the field differs from it
+	typedMu sync.Mutex
+	typed   string
+}
+
+// TypedShortcut calls OnUndo for Ctrl+Z and OnRedo for Ctrl+Y and Ctrl+Shift+Z, the other shortcuts are handled by the entry
+func (e *FyneEntry) TypedShortcut(shortcut fyne.Shortcut) {
+	switch {
+	case isUndoShortcut(shortcut) && e.OnUndo != nil:
+		e.OnUndo()
+	case isRedoShortcut(shortcut) && e.OnRedo != nil:
+		e.OnRedo()
+	default:
+		e.Entry.TypedShortcut(shortcut)
+	}
+}
+
+func isUndoShortcut(shortcut fyne.Shortcut) bool {
+	_, ok := shortcut.(*fyne.ShortcutUndo)
+	return ok
+}
+
+func isRedoShortcut(shortcut fyne.Shortcut) bool {
+	if _, ok := shortcut.(*fyne.ShortcutRedo); ok {
+		return true
+	}
+	custom, ok := shortcut.(*desktop.CustomShortcut)
+	return ok && custom.KeyName == fyne.KeyZ && custom.Modifier == fyne.KeyModifierShortcutDefault|fyne.KeyModifierShift
+}
+
+// entry returns the entry of a text, number or computed field. The entries are kept when the form is rendered again, so they keep the
+// focus and the text typed by the user, which may differ from the normalized value of the field (e.g. a trailing space removed by a
+// TrimNormalizer). When the user leaves the entry, it shows the normalized value and the field is marked as touched.
+// The caller has to hold the lock.
+func (r *fyneRenderer) entry(field Field, placeholder string) *FyneEntry {
+	base := getFieldBase(field)
+	if entry, ok := r.entries[field.GetId()]; ok {
+		// The text is only replaced if the value was changed by something else than the entry. The user may type while the form is
+		// refreshed, so the text typed last is compared instead of the current text.
+		entry.typedMu.Lock()
+		value := field.GetValue()
+		replace := entry.typed != value
+		entry.typed = value
+		entry.typedMu.Unlock()
+		if replace {
+			entry.SetText(value)
+		}
+		return entry
+	}
+	entry := &FyneEntry{typed: field.GetValue()}
+	entry.ExtendBaseWidget(entry)
+	entry.SetText(field.GetValue())
+	entry.SetPlaceHolder(placeholder)
+	entry.OnChanged = func(text string) {
+		entry.typedMu.Lock()
+		defer entry.typedMu.Unlock()
+		entry.typed = base.normalize(text)
+		field.SetValue(text)
+	}
+	form := r.form
+	entry.OnUndo = func() {
+		form.Undo()
+	}
+	entry.OnRedo = func() {
+		form.Redo()
+	}
+	entry.OnFocusLost = func() {
+		r.updates.run(func() {
+			if value := field.GetValue(); entry.Text != value {
+				entry.SetText(value)
+			}
+		})
+		form.MarkTouched(field.GetId())
+	}
+	r.entries[field.GetId()] = entry
+	return entry
+}
+
+func (e *FyneEntry) FocusLost() {
+	e.Entry.FocusLost()
+	if e.OnFocusLost != nil {
+		e.OnFocusLost()
+	}
+}
 
+// validateFields returns the displayed fields (and groups) that are invalid in the order of the form. The errors of the fields are only
+// updated if they are shown, the other fields are only validated if the submit button has to be disabled and keep their errors.
+func (r *fyneRenderer) validateFields(fields []Field) []Field {
+	var invalid []Field
 	for _, field := range fields {
-		switch field := field.(type) {
+		if _, ok := field.(*Message); ok {
+			continue
+		}
+		switch {
+		case r.submitAttempted || r.form.ShouldShowError(field.GetId()):
+			if !field.IsValid() {
+				invalid = append(invalid, field)
+			}
+		case r.presentation.DisableSubmit:
+			if !checkValid(field) {
+				invalid = append(invalid, field)
+			}
+		}
+		if group, ok := field.(*FieldGroup); ok {
+			invalid = append(invalid, r.validateFields(group.GetFieldsToDisplay())...)
+		}
+	}
+	return invalid
+}
+
+// fieldLabel returns the prompt, the title of a group or the id of the field
+func fieldLabel(field Field) string {
+	switch field := field.(type) {
+	case *FieldGroup:
+		return field.GetTitle()
+	case interface{ GetPrompt() string }:
+		if field.GetPrompt() != "" {
+			return field.GetPrompt()
+		}
+	}
+	return field.GetId()
+}
+
+// updateErrorState updates the summary banner and enables or disables the submit button
+func (r *fyneRenderer) updateErrorState() {
+	r.summary.RemoveAll()
+	if r.presentation.Summary && r.submitAttempted && len(r.invalid) > 0 {
+		title := widget.NewLabelWithStyle("Please fix the following problems:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		title.Importance = widget.DangerImportance
+		r.summary.Add(title)
+		for _, field := range r.invalid {
+			problem := widget.NewLabel("• " + fieldLabel(field) + ": " + field.GetError().Error())
+			problem.Importance = widget.DangerImportance
+			problem.Wrapping = fyne.TextWrapWord
+			r.summary.Add(problem)
+		}
+	}
+	r.summary.Refresh()
+	if r.submitting || r.presentation.DisableSubmit && len(r.invalid) > 0 {
+		r.submitButton.Disable()
+	} else {
+		r.submitButton.Enable()
+	}
+}
+
+func (r *fyneRenderer) fieldsToFyneForm(fields []Field) []*widget.FormItem {
+	var formItems []*widget.FormItem
+
+	for index := 0; index < len(fields); index++ {
+		if group, ok := fields[index].(*FieldGroup); ok && (group.Layout == GroupLayoutTab || group.Layout == GroupLayoutAccordion) {
+			groups := consecutiveGroups(fields[index:], group.Layout)
+			formItems = append(formItems, r.groupsToFyneFormItem(groups))
+			index += len(groups) - 1
+			continue
+		}
+		switch field := fields[index].(type) {
 		case *FieldBaseType:
 			// Do nothing
 		case *TextField:
-			entry := widget.NewEntry()
-			entry.SetText(field.GetValue())
-			entry.SetPlaceHolder(field.GetPlaceholder())
-			entry.OnChanged = func(text string) {
-				field.SetValue(text)
-				refreshForm(form, box, fyneForm)
-			}
-			entry.Validator = func(text string) error {
-				if !field.IsValid() {
-					return field.GetError()
-				}
-				return nil
-			}
-			formItems = append(formItems, widget.NewFormItem(field.GetPrompt(), entry))
+			formItems = append(formItems, r.newFormItem(field.GetPrompt(), field, r.entry(field, field.GetPlaceholder())))
 		case *MultipleChoiceField:
 			labelsToKeys := make(map[string]string)
 			options := make([]string, 0, len(field.GetOptions()))
@@ -45,39 +378,46 @@ func fieldsToFyneForm(fields []Field, form *Form, box *fyne.Container, fyneForm
 				options = append(options, option.Label)
 				labelsToKeys[option.Label] = key
 			}
-			selectWidget := widget.NewSelect(options, func(value string) {
-				key := labelsToKeys[value]
-				field.SetValue(key)
-			})
+			selectWidget := widget.NewSelect(options, nil)
 			selectWidget.SetSelected(field.Options[field.GetValue()].Label)
+			form := r.form
 			selectWidget.OnChanged = func(value string) {
 				key := labelsToKeys[value]
+				// Choosing an option is like leaving the field, so the select marks it as touched right away
+				form.MarkTouched(field.Id)
 				field.SetValue(key)
-				refreshForm(form, box, fyneForm)
 			}
-			formItems = append(formItems, widget.NewFormItem(field.GetPrompt(), selectWidget))
+			var below []fyne.CanvasObject
+			if description := field.Options[field.GetValue()].Description; description != "" {
+				// The description of the selected option is shown in a label under the select
+				descriptionLabel := widget.NewLabelWithStyle(description, fyne.TextAlignLeading, fyne.TextStyle{Italic: true})
+				descriptionLabel.Wrapping = fyne.TextWrapWord
+				below = append(below, descriptionLabel)
+			}
+			formItems = append(formItems, r.newFormItem(field.GetPrompt(), field, selectWidget, below...))
 		case *Message:
-			formItems = append(formItems, widget.NewFormItem(field.GetValue(), widget.NewLabel("")))
+			formItems = append(formItems, r.newFormItem(field.GetValue(), field, widget.NewLabel("")))
 		case *NumberField:
-			entry := widget.NewEntry()
-			entry.SetText(field.GetValue())
-			entry.SetPlaceHolder(field.GetPlaceholder())
-			entry.OnChanged = func(text string) {
-				field.SetValue(text)
-				refreshForm(form, box, fyneForm)
-			}
-			entry.Validator = func(text string) error {
-				if !field.IsValid() {
-					return field.GetError()
-				}
-				return nil
-			}
-			formItems = append(formItems, widget.NewFormItem(field.GetPrompt(), entry))
+			formItems = append(formItems, r.newFormItem(field.GetPrompt(), field, r.entry(field, field.GetPlaceholder())))
+		case *ComputedField:
+			formItems = append(formItems, r.newFormItem(field.GetPrompt(), field, r.entry(field, "")))
 		case *FieldGroup:
-			if field.GetHeading() != "" {
-				formItems = append(formItems, widget.NewFormItem(field.GetHeading(), widget.NewLabel("")))
+			switch field.Layout {
+			case GroupLayoutCard:
+				card := widget.NewCard(field.GetHeading(), "", widget.NewForm(r.fieldsToFyneForm(field.GetFieldsToDisplay())...))
+				formItems = append(formItems, r.newFormItem("", field, card))
+			case GroupLayoutGrid:
+				var cells []fyne.CanvasObject
+				for _, child := range field.GetFieldsToDisplay() {
+					cells = append(cells, widget.NewForm(r.fieldsToFyneForm([]Field{child})...))
+				}
+				formItems = append(formItems, r.newFormItem(field.GetHeading(), field, container.NewGridWithColumns(field.GetColumns(), cells...)))
+			default:
+				if field.GetHeading() != "" {
+					formItems = append(formItems, r.newFormItem(field.GetHeading(), field, widget.NewLabel("")))
+				}
+				formItems = append(formItems, r.fieldsToFyneForm(field.GetFieldsToDisplay())...)
 			}
-			formItems = append(formItems, fieldsToFyneForm(field.GetFieldsToDisplay(), form, box, fyneForm)...)
 		default:
 			panic("Unknown field type")
 		}
@@ -85,36 +425,598 @@ func fieldsToFyneForm(fields []Field, form *Form, box *fyne.Container, fyneForm
 	return formItems
 }
 
-// FormToFyneForm converts a Form to a Fyne form and adds it to the provided container.
+// consecutiveGroups returns the groups with the given layout at the start of the fields
+func consecutiveGroups(fields []Field, layout GroupLayout) []*FieldGroup {
+	var groups []*FieldGroup
+	for _, field := range fields {
+		group, ok := field.(*FieldGroup)
+		if !ok || group.Layout != layout {
+			break
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// groupsToFyneFormItem renders consecutive groups as tabs or accordion, the selected tab and the open sections are kept when the form is refreshed
+func (r *fyneRenderer) groupsToFyneFormItem(groups []*FieldGroup) *widget.FormItem {
+	var object fyne.CanvasObject
+	if groups[0].Layout == GroupLayoutTab {
+		tabs := container.NewAppTabs()
+		for index, group := range groups {
+			tabs.Append(container.NewTabItem(group.GetTitle(), widget.NewForm(r.fieldsToFyneForm(group.GetFieldsToDisplay())...)))
+			r.tabs[group.Id] = tabs
+			r.tabIndexes[group.Id] = index
+		}
+		key := groups[0].Id
+		if selected, ok := r.selectedTabs[key]; ok && selected < len(groups) {
+			tabs.SelectIndex(selected)
+		}
+		tabs.OnSelected = func(_ *container.TabItem) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.selectedTabs[key] = tabs.SelectedIndex()
+		}
+		object = tabs
+	} else {
+		accordion := widget.NewAccordion()
+		accordion.MultiOpen = true
+		for _, group := range groups {
+			item := widget.NewAccordionItem(group.GetTitle(), widget.NewForm(r.fieldsToFyneForm(group.GetFieldsToDisplay())...))
+			item.Open = r.openGroups[group.Id]
+			r.accordionItems[group.Id] = item
+			accordion.Append(item)
+		}
+		object = accordion
+	}
+	for _, group := range groups[1:] {
+		r.widgets[group.Id] = object
+	}
+	return r.newFormItem("", groups[0], object)
+}
+
+// resetWidgets forgets the rendered widgets before the form is rendered again and remembers which accordion sections were open
+func (r *fyneRenderer) resetWidgets() {
+	for id, item := range r.accordionItems {
+		r.openGroups[id] = item.Open
+	}
+	r.widgets = make(map[string]fyne.CanvasObject)
+	r.shownErrors = make(map[string]error)
+	r.accordionItems = make(map[string]*widget.AccordionItem)
+	r.tabs = make(map[string]*container.AppTabs)
+	r.tabIndexes = make(map[string]int)
+}
+
+// render replaces the content of the container with the form, the error summary and the buttons (and the page navigation if the form is a wizard)
+func (r *fyneRenderer) render() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.form == nil {
+		return
+	}
+	r.fyneForm = widget.NewForm()
+	r.page = r.form.GetCurrentPage()
+	r.resetWidgets()
+	r.invalid = r.validateFields(r.form.GetFieldsToDisplay())
+	r.fyneForm.Items = r.fieldsToFyneForm(r.form.GetFieldsToDisplay())
+	r.fyneForm.Resize(fyne.NewSize(700, 400))
+	r.summary = container.NewVBox()
+	// The buttons are not part of the widget.Form so that the submit button can be enabled and disabled independently of the entries
+	r.submitButton = widget.NewButtonWithIcon("Submit", theme.ConfirmIcon(), func() {
+		r.updates.run(r.submit)
+	})
+	r.submitButton.Importance = widget.HighImportance
+	cancelButton := widget.NewButtonWithIcon("Cancel", theme.CancelIcon(), func() {
+		r.updates.run(r.cancel)
+	})
+	buttons := container.NewHBox(layout.NewSpacer(), cancelButton, r.submitButton)
+	r.box.RemoveAll()
+	if r.form.IsWizard() {
+		current, total := r.form.GetProgress()
+		progress := widget.NewProgressBar()
+		progress.Max = float64(total)
+		progress.SetValue(float64(current))
+		progress.TextFormatter = r.form.GetProgressText
+		r.box.Add(progress)
+		r.box.Add(widget.NewLabelWithStyle(r.form.GetCurrentPage().GetTitle(), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		if !r.form.IsLastPage() {
+			r.submitButton.SetText("Next")
+		}
+		form := r.form
+		backButton := widget.NewButton("Back", func() {
+			r.updates.run(func() {
+				form.PreviousPage()
+				r.changePage()
+			})
+		})
+		if r.form.IsFirstPage() {
+			backButton.Disable()
+		}
+		buttons.Objects = append([]fyne.CanvasObject{backButton}, buttons.Objects...)
+	}
+	r.box.Add(r.summary)
+	r.box.Add(r.fyneForm)
+	r.box.Add(buttons)
+	r.updateErrorState()
+	r.box.Refresh()
+}
+
+// changePage renders the new wizard page, the errors of its fields are shown once they are touched or a submit was attempted
+func (r *fyneRenderer) changePage() {
+	r.mu.Lock()
+	r.submitAttempted = false
+	r.mu.Unlock()
+	r.render()
+	r.onPageChanged()
+}
+
+// submit validates the form and calls onSubmit or shows the errors. It runs on the goroutine updating the widgets, if the form has
+// asynchronous validators they are awaited on another goroutine and the result is handed back to the updates.
+func (r *fyneRenderer) submit() {
+	r.mu.Lock()
+	form := r.form
+	submitting := r.submitting
+	r.mu.Unlock()
+	if form == nil || submitting {
+		return
+	}
+	if !form.IsLastPage() {
+		if err := form.NextPage(); err != nil {
+			r.showErrors(err)
+			return
+		}
+		r.changePage()
+		return
+	}
+	if form.hasAsyncValidators() {
+		r.setSubmitting(true)
+		go func() {
+			err := form.ValidateContext(context.Background())
+			r.updates.run(func() {
+				r.setSubmitting(false)
+				if err != nil {
+					r.showErrors(err)
+					return
+				}
+				r.onSubmit(form.GetFieldValues())
+			})
+		}()
+		return
+	}
+	if form.IsValid() {
+		r.onSubmit(
+			form.GetFieldValues(),
+		)
+	} else {
+		r.showErrors(form.GetError())
+	}
+}
+
+// setSubmitting disables the submit button while a submit is pending and enables it again afterwards unless the form is invalid
+func (r *fyneRenderer) setSubmitting(submitting bool) {
+	r.mu.Lock()
+	r.submitting = submitting
+	r.mu.Unlock()
+	if submitting {
+		r.submitButton.Disable()
+	} else {
+		r.refresh()
+	}
+}
+
+// cancel calls onCancel, if configured the user has to confirm discarding the changes of a dirty form first.
+// Nothing happens once the renderer is detached from the form.
+func (r *fyneRenderer) cancel() {
+	r.mu.Lock()
+	form := r.form
+	r.mu.Unlock()
+	if form == nil {
+		return
+	}
+	if r.window == nil || !r.confirmCancel() || !form.IsDirty() {
+		r.onCancel()
+		return
+	}
+	confirm := dialog.NewConfirm("Discard changes?", "The form has unsaved changes.", func(discard bool) {
+		if discard {
+			r.updates.run(r.onCancel)
+		}
+	}, r.window)
+	confirm.SetConfirmText("Discard")
+	confirm.SetDismissText("Keep editing")
+	confirm.Show()
+}
+
+// showErrors presents the errors after a failed submit attempt as configured in the error presentation
+func (r *fyneRenderer) showErrors(err error) {
+	r.mu.Lock()
+	r.submitAttempted = true
+	presentation := r.presentation
+	r.mu.Unlock()
+	r.refresh()
+	if presentation.FocusFirstInvalid {
+		r.mu.Lock()
+		invalid := r.invalid
+		r.mu.Unlock()
+		for _, field := range invalid {
+			if r.focusField(field.GetId()) {
+				break
+			}
+		}
+	}
+	if presentation.Dialog {
+		dialog.ShowError(err, r.window)
+	}
+}
+
+func (r *fyneRenderer) getWidget(id string) fyne.CanvasObject {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.widgets[id]
+}
+
+// attach queues the rendering of the form and adds the listeners keeping the rendered form up to date
+func (r *fyneRenderer) attach(form *Form) {
+	r.mu.Lock()
+	r.form = form
+	r.entries = make(map[string]*FyneEntry)
+	r.selectedTabs = make(map[string]int)
+	r.openGroups = make(map[string]bool)
+	r.submitAttempted = false
+	r.removeListeners = []func(){
+		form.addChangeListener(r.queueRefresh),
+		form.addValidationListener(func(_ string) {
+			r.queueRefresh()
+		}),
+	}
+	r.mu.Unlock()
+	r.updates.run(r.render)
+}
+
+// detach removes the listeners from the form, the rendered widgets are not updated anymore
+func (r *fyneRenderer) detach() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, removeListener := range r.removeListeners {
+		removeListener()
+	}
+	r.removeListeners = nil
+	r.form = nil
+	r.fyneForm = nil
+}
+
+// canFocus returns true if the field with the given id is displayed on the current page and rendered as a widget that can be focused
+func canFocus(form *Form, id string) bool {
+	field := form.lookupField(id)
+	base := getFieldBase(field)
+	if base == nil || !base.IsEditable() || !isDisplayed(form, field) {
+		return false
+	}
+	if page := form.GetCurrentPage(); page != nil && !slices.Contains(flattenFields(page.Fields), field) {
+		return false
+	}
+	switch field.(type) {
+	case *TextField, *NumberField, *ComputedField, *MultipleChoiceField:
+		return true
+	}
+	return false
+}
+
+// focusField reveals, focuses and scrolls to the widget of the field with the given id and returns false if it cannot be focused
+func (r *fyneRenderer) focusField(id string) bool {
+	r.revealField(id)
+	focusable, ok := r.getWidget(id).(fyne.Focusable)
+	if !ok {
+		return false
+	}
+	canvas := fyne.CurrentApp().Driver().CanvasForObject(focusable.(fyne.CanvasObject))
+	if canvas == nil && r.window != nil {
+		canvas = r.window.Canvas()
+	}
+	if canvas == nil {
+		return false
+	}
+	canvas.Focus(focusable)
+	r.scrollTo(canvas, focusable.(fyne.CanvasObject))
+	return true
+}
+
+// scrollTo scrolls the scroll containers around the form so that the object is visible, the innermost one is scrolled first
+func (r *fyneRenderer) scrollTo(canvas fyne.Canvas, object fyne.CanvasObject) {
+	driver := fyne.CurrentApp().Driver()
+	for _, root := range append([]fyne.CanvasObject{canvas.Content()}, canvas.Overlays().List()...) {
+		scrolls, ok := scrollsAround(root, r.formWidget)
+		if !ok {
+			continue
+		}
+		for index := len(scrolls) - 1; index >= 0; index-- {
+			scroll := scrolls[index]
+			// The content is laid out first because it may have grown since the last layout (e.g. by the inline errors)
+			scroll.Refresh()
+			// The position of the object in the content of the scroll container
+			position := driver.AbsolutePositionForObject(object).Subtract(driver.AbsolutePositionForObject(scroll)).Add(scroll.Offset)
+			scroll.Offset = fyne.NewPos(
+				scrollOffset(scroll.Offset.X, scroll.Size().Width, position.X, object.Size().Width),
+				scrollOffset(scroll.Offset.Y, scroll.Size().Height, position.Y, object.Size().Height),
+			)
+			scroll.Refresh()
+		}
+		return
+	}
+}
+
+// scrollsAround returns the scroll containers around the object from the outermost to the innermost and false if the object is not
+// part of the tree. Only containers and popups are searched, the form is usually placed in them by the application.
+func scrollsAround(root fyne.CanvasObject, object fyne.CanvasObject) ([]*container.Scroll, bool) {
+	if root == object {
+		return nil, true
+	}
+	switch root := root.(type) {
+	case *container.Scroll:
+		if scrolls, ok := scrollsAround(root.Content, object); ok {
+			return append([]*container.Scroll{root}, scrolls...), true
+		}
+	case *fyne.Container:
+		for _, child := range root.Objects {
+			if scrolls, ok := scrollsAround(child, object); ok {
+				return scrolls, true
+			}
+		}
+	case *widget.PopUp:
+		return scrollsAround(root.Content, object)
+	}
+	return nil, false
+}
+
+// scrollOffset returns the offset of a scroll container along one axis that shows the object with the given position and size,
+// the offset is only changed if the object is not fully visible
+func scrollOffset(offset float32, viewport float32, position float32, size float32) float32 {
+	switch {
+	case position < offset || size > viewport:
+		return position
+	case position+size > offset+viewport:
+		return position + size - viewport
+	default:
+		return offset
+	}
+}
+
+// revealField selects the tabs and opens the accordion sections containing the field with the given id
+func (r *fyneRenderer) revealField(id string) {
+	// The widgets are changed after unlocking because selecting a tab calls OnSelected, which locks the renderer
+	var reveal []func()
+	r.mu.Lock()
+	for _, group := range r.form.getParentGroups(id) {
+		if tabs, ok := r.tabs[group.Id]; ok && tabs.SelectedIndex() != r.tabIndexes[group.Id] {
+			index := r.tabIndexes[group.Id]
+			reveal = append(reveal, func() { tabs.SelectIndex(index) })
+		}
+		if item, ok := r.accordionItems[group.Id]; ok && !item.Open {
+			if accordion, ok := r.widgets[group.Id].(*widget.Accordion); ok {
+				r.openGroups[group.Id] = true
+				index := slices.Index(accordion.Items, item)
+				reveal = append(reveal, func() { accordion.Open(index) })
+			}
+		}
+	}
+	r.mu.Unlock()
+	for _, action := range reveal {
+		action()
+	}
+}
+
+// FyneErrorPresentation configures how a FyneForm presents validation errors
+type FyneErrorPresentation struct {
+	// Inline shows the error under each invalid field once the validation mode of the form allows it (see Form.ShouldShowError)
+	// or the user tried to submit the form
+	Inline bool
+	// Summary shows a banner listing the errors of all invalid fields above the form after a failed submit
+	Summary bool
+	// Dialog shows the first error in a dialog after a failed submit
+	Dialog bool
+	// DisableSubmit disables the submit button until the form (or the wizard page) is valid
+	DisableSubmit bool
+	// FocusFirstInvalid focuses the first invalid field after a failed submit
+	FocusFirstInvalid bool
+}
+
+// DefaultFyneErrorPresentation shows inline errors and a dialog and focuses the first invalid field after a failed submit
+func DefaultFyneErrorPresentation() FyneErrorPresentation {
+	return FyneErrorPresentation{Inline: true, Dialog: true, FocusFirstInvalid: true}
+}
+
+// FyneForm is a Fyne widget rendering a Form, it can be placed anywhere in a layout.
+// Wizard forms are displayed page by page with a progress bar and back and next buttons.
+type FyneForm struct {
+	widget.BaseWidget
+	// OnSubmit is called with the values of the valid form, OnCancel when the cancel button is tapped
+	OnSubmit func(values map[string]string)
+	OnCancel func()
+	// OnChanged is called after the widgets were updated because the form changed, OnPageChanged after the wizard page changed.
+	// All callbacks run on the goroutine updating the widgets (see WaitForUpdates).
+	OnChanged     func()
+	OnPageChanged func(page *WizardPage)
+	// ConfirmCancel asks the user to confirm discarding the changes before OnCancel is called if the form is dirty (see Form.IsDirty).
+	// It is enabled by NewFyneForm.
+	ConfirmCancel bool
+
+	renderer *fyneRenderer
+	// initial is the snapshot of the form when it was set, Reset restores it
+	initial *Draft
+}
+
+// NewFyneForm creates a widget rendering the form, the window is used for dialogs
+func NewFyneForm(form *Form, window fyne.Window) *FyneForm {
+	w := &FyneForm{ConfirmCancel: true}
+	w.ExtendBaseWidget(w)
+	w.renderer = &fyneRenderer{
+		updates:      newFyneUpdates(),
+		formWidget:   w,
+		box:          container.New(layout.NewVBoxLayout()),
+		window:       window,
+		presentation: DefaultFyneErrorPresentation(),
+		onSubmit: func(values map[string]string) {
+			if w.OnSubmit != nil {
+				w.OnSubmit(values)
+			}
+		},
+		onCancel: func() {
+			if w.OnCancel != nil {
+				w.OnCancel()
+			}
+		},
+		onChanged: func() {
+			if w.OnChanged != nil {
+				w.OnChanged()
+			}
+		},
+		onPageChanged: func() {
+			if w.OnPageChanged != nil {
+				w.OnPageChanged(w.GetForm().GetCurrentPage())
+			}
+		},
+		confirmCancel: func() bool {
+			return w.ConfirmCancel
+		},
+	}
+	w.SetForm(form)
+	return w
+}
+
+func (w *FyneForm) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(w.renderer.box)
+}
+
+// GetForm returns the rendered form or nil if the widget was destroyed
+func (w *FyneForm) GetForm() *Form {
+	w.renderer.mu.Lock()
+	defer w.renderer.mu.Unlock()
+	return w.renderer.form
+}
+
+// SetForm replaces the rendered form, the widget stops listening to the previous form. The form is rendered asynchronously.
+func (w *FyneForm) SetForm(form *Form) {
+	w.renderer.detach()
+	w.initial = form.snapshot()
+	w.renderer.attach(form)
+}
+
+// Submit validates the form like the submit button and calls OnSubmit if it is valid (or shows the next page of a wizard).
+// Like a tap on the button it is queued and returns before the form is validated.
+func (w *FyneForm) Submit() {
+	if w.GetForm() != nil {
+		w.renderer.updates.run(w.renderer.submit)
+	}
+}
+
+// Reset restores the values and the wizard page the form had when it was set, marks the form as pristine and hides the errors.
+// The reset of the values can be undone.
+func (w *FyneForm) Reset() error {
+	form := w.GetForm()
+	if form == nil {
+		return nil
+	}
+	err := form.ApplyDraft(w.initial)
+	form.MarkPristine()
+	w.renderer.updates.run(func() {
+		w.renderer.mu.Lock()
+		w.renderer.submitAttempted = false
+		w.renderer.mu.Unlock()
+		w.renderer.render()
+	})
+	return err
+}
+
+// FocusField focuses the widget of the field with the given id, tabs and accordion sections containing it are opened.
+// It returns false if the field is not displayed or cannot be focused, the widget is focused asynchronously.
+func (w *FyneForm) FocusField(id string) bool {
+	form := w.GetForm()
+	if form == nil || !canFocus(form, id) {
+		return false
+	}
+	w.renderer.updates.run(func() {
+		w.renderer.focusField(id)
+	})
+	return true
+}
+
+// FieldError returns the error shown under the field with the given id or nil if no error is shown
+func (w *FyneForm) FieldError(id string) error {
+	w.renderer.mu.Lock()
+	defer w.renderer.mu.Unlock()
+	return w.renderer.shownErrors[id]
+}
+
+// GetErrorPresentation returns how the widget presents validation errors
+func (w *FyneForm) GetErrorPresentation() FyneErrorPresentation {
+	w.renderer.mu.Lock()
+	defer w.renderer.mu.Unlock()
+	return w.renderer.presentation
+}
+
+// SetErrorPresentation changes how the widget presents validation errors and renders the form again
+func (w *FyneForm) SetErrorPresentation(presentation FyneErrorPresentation) {
+	w.renderer.mu.Lock()
+	w.renderer.presentation = presentation
+	w.renderer.mu.Unlock()
+	w.renderer.updates.run(w.renderer.render)
+}
+
+// FieldWidget returns the widget rendered for the field with the given id or nil if the field is not displayed
+func (w *FyneForm) FieldWidget(id string) fyne.CanvasObject {
+	return w.renderer.getWidget(id)
+}
+
+// WaitForUpdates waits until the queued updates of the widgets (e.g. after a change of the form) are done.
+// The widgets are updated on their own goroutine, which also runs the callbacks, so it must not be called from the callbacks.
+func (w *FyneForm) WaitForUpdates() {
+	w.renderer.updates.wait()
+}
+
+// Update queues a change of the widgets, it runs after the queued updates on their goroutine. Changes made by other goroutines than
+// Fyne's (e.g. by tests acting like the user) must use it, otherwise they race with the updates of the widgets.
+func (w *FyneForm) Update(update func()) {
+	w.renderer.updates.run(update)
+}
+
+// Destroy stops updating the widget and removes the listeners from the form
+func (w *FyneForm) Destroy() {
+	w.renderer.detach()
+	w.renderer.updates.run(func() {
+		w.renderer.box.RemoveAll()
+		w.Refresh()
+	})
+}
+
+// FyneFieldWidget returns the widget rendered by FormToFyneForm into the container for the field with the given id.
+// It returns nil if the field is not displayed, which makes it useful for tests of the rendered form.
+func FyneFieldWidget(box *fyne.Container, id string) fyne.CanvasObject {
+	for _, object := range box.Objects {
+		if formWidget, ok := object.(*FyneForm); ok {
+			return formWidget.FieldWidget(id)
+		}
+	}
+	return nil
+}
+
+// FormToFyneForm renders the form with a FyneForm widget and replaces the content of the provided container with it.
+// Wizard forms are displayed page by page with a progress bar and back and next buttons.
 func FormToFyneForm(
 	form *Form,
 	box *fyne.Container,
 	window fyne.Window,
 	onSubmit func(values map[string]string),
 	onCancel func(),
-) {
-	fields := form.GetFieldsToDisplay()
-	fyneForm := widget.NewForm()
-	fyneForm.Items = fieldsToFyneForm(fields, form, box, fyneForm)
-	fyneForm.OnSubmit = func() {
-		if form.IsValid() {
-			onSubmit(
-				form.GetFieldValues(),
-			)
-		} else {
-			dialog.ShowError(form.GetError(), window)
-		}
-	}
-	fyneForm.OnCancel = func() {
-		onCancel()
-	}
-	fyneForm.Resize(fyne.NewSize(700, 400))
+) *FyneForm {
+	formWidget := NewFyneForm(form, window)
+	formWidget.OnSubmit = onSubmit
+	formWidget.OnCancel = onCancel
 	box.RemoveAll()
-	box.Add(fyneForm)
-	box.Refresh()
+	box.Add(formWidget)
+	return formWidget
 }
 
-// FormToFynePopup converts a Form to a Fyne popup and displays it.
+// FormToFynePopup renders the form with a FyneForm widget in a popup and displays it, the widget is destroyed when the popup is closed.
 func FormToFynePopup(
 	titel string,
 	size fyne.Size,
@@ -122,20 +1024,20 @@ func FormToFynePopup(
 	window fyne.Window,
 	onSubmit func(values map[string]string),
 	onCancel func(),
-) {
-	box := container.New(layout.NewVBoxLayout())
-	formPopup := dialog.NewCustomWithoutButtons(titel, box, window)
+) *FyneForm {
+	formWidget := NewFyneForm(form, window)
+	formPopup := dialog.NewCustomWithoutButtons(titel, formWidget, window)
 	formPopup.Resize(size)
 
-	submitCallback := func(values map[string]string) {
+	formWidget.OnSubmit = func(values map[string]string) {
 		onSubmit(values)
 		formPopup.Hide()
 	}
-	cancelCallback := func() {
+	formWidget.OnCancel = func() {
 		onCancel()
 		formPopup.Hide()
 	}
-
-	FormToFyneForm(form, box, window, submitCallback, cancelCallback)
+	formPopup.SetOnClosed(formWidget.Destroy)
 	formPopup.Show()
+	return formWidget
 }
@@ -0,0 +1,105 @@
+package go_forms
+
+// Defining the handling of the values of hidden fields
+
+// HiddenFieldPolicy decides what happens to the values of fields that are not displayed because a display condition of the field,
+// of a group containing it or of its wizard page is not met
+type HiddenFieldPolicy int
+
+const (
+	// KeepHiddenValues keeps the values of hidden fields and returns them like the values of displayed fields
+	KeepHiddenValues HiddenFieldPolicy = iota
+	// ExcludeHiddenValues keeps the values of hidden fields but leaves them out of the values and drafts of the form,
+	// they are used again when the fields are displayed again
+	ExcludeHiddenValues
+	// ClearHiddenValues empties fields when they become hidden
+	ClearHiddenValues
+	// ResetHiddenValues sets fields to their default when they become hidden (see FieldBaseType.GetDefault)
+	ResetHiddenValues
+)
+
+func (f *Form) GetHiddenFieldPolicy() HiddenFieldPolicy {
+	f.stateMu.RLock()
+	defer f.stateMu.RUnlock()
+	return f.hiddenFieldPolicy
+}
+
+// SetHiddenFieldPolicy sets what happens to the values of hidden fields. Fields that are hidden already are not changed,
+// ClearHiddenValues and ResetHiddenValues only apply to fields that become hidden afterwards.
+func (f *Form) SetHiddenFieldPolicy(policy HiddenFieldPolicy) {
+	f.stateMu.Lock()
+	f.hiddenFieldPolicy = policy
+	f.stateMu.Unlock()
+	// The display conditions are evaluated without holding the lock, they may change the form
+	var hidden map[string]bool
+	if policy == ClearHiddenValues || policy == ResetHiddenValues {
+		hidden = f.getHiddenFields()
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hidden = hidden
+}
+
+// getHiddenFields returns the ids of the fields that are not displayed
+func (f *Form) getHiddenFields() map[string]bool {
+	hidden := make(map[string]bool)
+	for _, field := range f.GetAllFields() {
+		if !isDisplayed(f, field) {
+			hidden[field.GetId()] = true
+		}
+	}
+	return hidden
+}
+
+// applyHiddenFieldPolicy clears or resets the fields that became hidden since the last call and returns the changes and the ids of all
+// changed fields including the computed ones. Changed values may hide more fields, so this is repeated until nothing changes.
+// It is called while a change is applied (see propagate), the form must not be locked.
+func (f *Form) applyHiddenFieldPolicy() ([]valueChange, []string) {
+	policy := f.GetHiddenFieldPolicy()
+	if policy != ClearHiddenValues && policy != ResetHiddenValues {
+		return nil, nil
+	}
+	var changes []valueChange
+	var changedIds []string
+	for {
+		hidden := f.getHiddenFields()
+		f.mu.Lock()
+		previous := f.hidden
+		f.hidden = hidden
+		f.mu.Unlock()
+		var round []valueChange
+		for _, field := range f.GetAllFields() {
+			if !hidden[field.GetId()] || previous[field.GetId()] {
+				continue
+			}
+			switch field.(type) {
+			case *Message, *FieldGroup, *ComputedField:
+				continue
+			}
+			base := getFieldBase(field)
+			if base == nil || base.IsReadOnly() {
+				continue
+			}
+			value := ""
+			if policy == ResetHiddenValues {
+				value = base.GetDefault()
+			}
+			if oldValue := base.storeValue(value); oldValue != value {
+				round = append(round, valueChange{fieldId: base.Id, oldValue: oldValue, newValue: value})
+			}
+		}
+		if len(round) == 0 {
+			return changes, changedIds
+		}
+		for _, change := range round {
+			changedIds = append(changedIds, change.fieldId)
+			changedIds = append(changedIds, f.updateComputedFields(change.fieldId)...)
+		}
+		changes = append(changes, round...)
+	}
+}
+
+// excludesValue returns true if the value of the field is left out of the values of the form because it is hidden
+func (f *Form) excludesValue(field Field) bool {
+	return f != nil && f.GetHiddenFieldPolicy() == ExcludeHiddenValues && !isDisplayed(f, field)
+}
@@ -0,0 +1,102 @@
+package go_forms
+
+import (
+	"maps"
+	"testing"
+)
+
+func newHiddenForm(policy HiddenFieldPolicy) *Form {
+	form := NewForm(
+		NewMultipleChoiceField("software", nil, nil, "", "Software", map[string]Option{"paper": {Label: "Paper"}, "vanilla": {Label: "Vanilla"}}, "paper"),
+		NewNumberField("ram", []DisplayCondition{&HasValueDisplayCondition{FieldId: "software", Value: "paper"}}, nil, "", "RAM", 2048),
+	)
+	form.SetHistoryOptions(DefaultHistoryLimit, 0)
+	form.SetHiddenFieldPolicy(policy)
+	return form
+}
+
+func TestHiddenFieldPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy HiddenFieldPolicy
+		// hidden is the value of ram once it is hidden, values are the values of the form at that point
+		hidden string
+		values map[string]string
+		// shown is the value of ram once it is displayed again
+		shown string
+	}{
+		{
+			name:   "keep",
+			policy: KeepHiddenValues,
+			hidden: "4096",
+			values: map[string]string{"software": "vanilla", "ram": "4096"},
+			shown:  "4096",
+		},
+		{
+			name:   "exclude",
+			policy: ExcludeHiddenValues,
+			hidden: "4096",
+			values: map[string]string{"software": "vanilla"},
+			shown:  "4096",
+		},
+		{
+			name:   "clear",
+			policy: ClearHiddenValues,
+			hidden: "",
+			values: map[string]string{"software": "vanilla", "ram": ""},
+			shown:  "",
+		},
+		{
+			name:   "reset",
+			policy: ResetHiddenValues,
+			hidden: "2048",
+			values: map[string]string{"software": "vanilla", "ram": "2048"},
+			shown:  "2048",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			form := newHiddenForm(test.policy)
+			software, ram := form.lookupField("software"), form.lookupField("ram")
+			ram.SetValue("4096")
+			software.SetValue("vanilla")
+			if ram.GetValue() != test.hidden {
+				t.Errorf("hidden ram is %q, expected %q", ram.GetValue(), test.hidden)
+			}
+			if values := form.GetFieldValues(); !maps.Equal(values, test.values) {
+				t.Errorf("values are %v, expected %v", values, test.values)
+			}
+			// The policy is undone together with the change that hid the field
+			if !form.Undo() {
+				t.Fatalf("hiding the field cannot be undone")
+			}
+			if software.GetValue() != "paper" || ram.GetValue() != "4096" {
+				t.Errorf("values after undo are %v, expected software paper and ram 4096", form.GetAllFieldValues())
+			}
+			if !form.Redo() {
+				t.Fatalf("hiding the field cannot be redone")
+			}
+			if ram.GetValue() != test.hidden {
+				t.Errorf("ram after redo is %q, expected %q", ram.GetValue(), test.hidden)
+			}
+			software.SetValue("paper")
+			if ram.GetValue() != test.shown {
+				t.Errorf("ram displayed again is %q, expected %q", ram.GetValue(), test.shown)
+			}
+			if values := form.GetFieldValues(); values["ram"] != test.shown {
+				t.Errorf("values of the displayed field are %v, expected ram %q", values, test.shown)
+			}
+		})
+	}
+}
+
+func TestSetHiddenFieldPolicyKeepsHiddenValues(t *testing.T) {
+	form := newHiddenForm(KeepHiddenValues)
+	form.lookupField("ram").SetValue("4096")
+	form.lookupField("software").SetValue("vanilla")
+	// Fields that are hidden already are not cleared when the policy changes
+	form.SetHiddenFieldPolicy(ClearHiddenValues)
+	if value := form.lookupField("ram").GetValue(); value != "4096" {
+		t.Errorf("ram hidden before the policy changed is %q, expected 4096", value)
+	}
+}
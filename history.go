@@ -0,0 +1,169 @@
+package go_forms
+
+import "time"
+
+const (
+	// DefaultHistoryLimit is the default number of undo steps a form remembers
+	DefaultHistoryLimit = 100
+	// DefaultHistoryGroupInterval is the default time in which changes of the same field are merged into one undo step
+	DefaultHistoryGroupInterval = time.Second
+)
+
+type valueChange struct {
+	fieldId  string
+	oldValue string
+	newValue string
+}
+
+type historyEntry struct {
+	changes []valueChange
+	time    time.Time
+	// step identifies the entry, so that changes applied later can be added to it (see extend)
+	step int
+}
+
+// history holds the undo steps of a form, entries before position can be undone and entries from position on can be redone
+type history struct {
+	entries       []historyEntry
+	position      int
+	lastStep      int
+	limit         int
+	groupInterval time.Duration
+}
+
+func newHistory() history {
+	return history{limit: DefaultHistoryLimit, groupInterval: DefaultHistoryGroupInterval}
+}
+
+// record adds the changes as a new undo step or merges them into the last one and returns the step, 0 if nothing was recorded
+func (h *history) record(changes []valueChange) int {
+	if len(changes) == 0 {
+		return 0
+	}
+	now := time.Now()
+	// Changes are only merged into the last step if it was not undone
+	canMerge := h.position == len(h.entries) && h.position > 0
+	h.entries = h.entries[:h.position]
+	if len(changes) == 1 && canMerge {
+		last := &h.entries[h.position-1]
+		if len(last.changes) == 1 && last.changes[0].fieldId == changes[0].fieldId && now.Sub(last.time) < h.groupInterval {
+			// A step that changes nothing (e.g. a typed character that was deleted again) is dropped
+			if last.changes[0].oldValue == changes[0].newValue {
+				h.entries = h.entries[:h.position-1]
+				h.position--
+				return 0
+			}
+			last.changes[0].newValue = changes[0].newValue
+			last.time = now
+			return last.step
+		}
+	}
+	h.lastStep++
+	h.entries = append(h.entries, historyEntry{changes: changes, time: now, step: h.lastStep})
+	if h.limit > 0 && len(h.entries) > h.limit {
+		h.entries = h.entries[len(h.entries)-h.limit:]
+	}
+	h.position = len(h.entries)
+	return h.lastStep
+}
+
+// extend adds changes caused by the given step (e.g. cleared hidden fields) to it, so that they are undone together.
+// Nothing is added if the step is not the last one anymore or was undone.
+func (h *history) extend(step int, changes []valueChange) {
+	if len(changes) == 0 || h.position == 0 || h.position != len(h.entries) || h.entries[h.position-1].step != step {
+		return
+	}
+	last := &h.entries[h.position-1]
+	last.changes = append(last.changes, changes...)
+}
+
+// SetHistoryOptions sets the maximum number of undo steps and the time in which changes of the same field are merged into one step
+func (f *Form) SetHistoryOptions(limit int, groupInterval time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.history.limit = limit
+	f.history.groupInterval = groupInterval
+	if limit > 0 && len(f.history.entries) > limit {
+		removed := len(f.history.entries) - limit
+		f.history.entries = f.history.entries[removed:]
+		f.history.position = max(f.history.position-removed, 0)
+	}
+}
+
+// ClearHistory removes all undo and redo steps
+func (f *Form) ClearHistory() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.history.entries = nil
+	f.history.position = 0
+}
+
+func (f *Form) CanUndo() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.history.position > 0
+}
+
+func (f *Form) CanRedo() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.history.position < len(f.history.entries)
+}
+
+// Undo reverts the last change made with SetValue, returns false if there is nothing to undo
+func (f *Form) Undo() bool {
+	f.mu.Lock()
+	if f.history.position == 0 {
+		f.mu.Unlock()
+		return false
+	}
+	f.history.position--
+	changes := f.history.entries[f.history.position].changes
+	var changedIds []string
+	for index := len(changes) - 1; index >= 0; index-- {
+		if f.restoreValue(changes[index].fieldId, changes[index].oldValue) {
+			changedIds = append(changedIds, changes[index].fieldId)
+		}
+	}
+	// The hidden fields of the step are restored with it, applying the policy only updates which fields are hidden
+	f.propagate(pendingChange{ids: changedIds})
+	return true
+}
+
+// Redo applies the last undone change again, returns false if there is nothing to redo
+func (f *Form) Redo() bool {
+	f.mu.Lock()
+	if f.history.position == len(f.history.entries) {
+		f.mu.Unlock()
+		return false
+	}
+	changes := f.history.entries[f.history.position].changes
+	f.history.position++
+	var changedIds []string
+	for _, change := range changes {
+		if f.restoreValue(change.fieldId, change.newValue) {
+			changedIds = append(changedIds, change.fieldId)
+		}
+	}
+	f.propagate(pendingChange{ids: changedIds})
+	return true
+}
+
+// recordChange adds a change to the history and returns its step, the form has to be locked
+func (f *Form) recordChange(fieldId string, oldValue string, newValue string) int {
+	if oldValue == newValue {
+		return 0
+	}
+	return f.history.record([]valueChange{{fieldId: fieldId, oldValue: oldValue, newValue: newValue}})
+}
+
+// restoreValue sets the value of a field without recording it in the history, it returns false if the field does not exist.
+// The form has to be locked, the computed fields are updated when the change is propagated.
+func (f *Form) restoreValue(fieldId string, value string) bool {
+	base := getFieldBase(f.lookupField(fieldId))
+	if base == nil {
+		return false
+	}
+	base.storeValue(value)
+	return true
+}
@@ -0,0 +1,151 @@
+package go_forms
+
+import (
+	"maps"
+	"testing"
+	"time"
+)
+
+func newHistoryForm() *Form {
+	name := NewTextField("name", nil, nil, "", "Name", "")
+	motd := NewTextField("motd", nil, nil, "", "Message of the day", "")
+	title := NewComputedField("title", nil, nil, "Title", []string{"name", "motd"}, func(values map[string]string) string {
+		return values["name"] + ": " + values["motd"]
+	}, true)
+	form := NewForm(name, motd, title)
+	// Every change is its own step unless a test groups them
+	form.SetHistoryOptions(DefaultHistoryLimit, 0)
+	return form
+}
+
+func TestUndoRedo(t *testing.T) {
+	type step struct {
+		action string
+		id     string
+		value  string
+		ok     bool
+		values map[string]string
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "undo and redo",
+			steps: []step{
+				{action: "set", id: "name", value: "lobby"},
+				{action: "set", id: "motd", value: "Welcome"},
+				{action: "undo", ok: true, values: map[string]string{"name": "lobby", "motd": "", "title": "lobby: "}},
+				{action: "undo", ok: true, values: map[string]string{"name": "", "motd": "", "title": ": "}},
+				{action: "undo", ok: false, values: map[string]string{"name": "", "motd": "", "title": ": "}},
+				{action: "redo", ok: true, values: map[string]string{"name": "lobby", "motd": "", "title": "lobby: "}},
+				{action: "redo", ok: true, values: map[string]string{"name": "lobby", "motd": "Welcome", "title": "lobby: Welcome"}},
+				{action: "redo", ok: false, values: map[string]string{"name": "lobby", "motd": "Welcome", "title": "lobby: Welcome"}},
+			},
+		},
+		{
+			name: "a change drops the redo steps",
+			steps: []step{
+				{action: "set", id: "name", value: "lobby"},
+				{action: "undo", ok: true},
+				{action: "set", id: "motd", value: "Welcome"},
+				{action: "redo", ok: false, values: map[string]string{"name": "", "motd": "Welcome", "title": ": Welcome"}},
+			},
+		},
+		{
+			name: "setting the same value is no step",
+			steps: []step{
+				{action: "set", id: "name", value: "lobby"},
+				{action: "set", id: "name", value: "lobby"},
+				{action: "undo", ok: true},
+				{action: "undo", ok: false, values: map[string]string{"name": "", "motd": "", "title": ": "}},
+			},
+		},
+		{
+			name: "reset is one step",
+			steps: []step{
+				{action: "set", id: "name", value: "lobby"},
+				{action: "set", id: "motd", value: "Welcome"},
+				{action: "reset"},
+				{action: "undo", ok: true, values: map[string]string{"name": "lobby", "motd": "Welcome", "title": "lobby: Welcome"}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			form := newHistoryForm()
+			for index, step := range test.steps {
+				ok := false
+				switch step.action {
+				case "set":
+					form.lookupField(step.id).SetValue(step.value)
+					continue
+				case "reset":
+					form.Reset()
+					continue
+				case "undo":
+					ok = form.Undo()
+				case "redo":
+					ok = form.Redo()
+				}
+				if ok != step.ok {
+					t.Errorf("step %d: %s returned %v, expected %v", index, step.action, ok, step.ok)
+				}
+				if step.values != nil && !maps.Equal(form.GetAllFieldValues(), step.values) {
+					t.Errorf("step %d: values after %s are %v, expected %v", index, step.action, form.GetAllFieldValues(), step.values)
+				}
+			}
+		})
+	}
+}
+
+func TestHistoryOptions(t *testing.T) {
+	form := newHistoryForm()
+	form.SetHistoryOptions(2, time.Hour)
+	name := form.lookupField("name")
+	// Typing into the same field within the interval is one step
+	for _, value := range []string{"l", "lo", "lobby"} {
+		name.SetValue(value)
+	}
+	form.lookupField("motd").SetValue("Welcome")
+	name.SetValue("survival")
+	if !form.Undo() || !form.Undo() {
+		t.Fatalf("the last two steps cannot be undone")
+	}
+	if form.Undo() {
+		t.Errorf("more steps than the limit of 2 can be undone")
+	}
+	if values := form.GetAllFieldValues(); values["name"] != "lobby" || values["motd"] != "" {
+		t.Errorf("values after undoing the remembered steps are %v", values)
+	}
+	form.ClearHistory()
+	if form.CanUndo() || form.CanRedo() {
+		t.Errorf("steps are left after ClearHistory")
+	}
+}
+
+func TestMergedStepsThatChangeNothingAreDropped(t *testing.T) {
+	form := newHistoryForm()
+	form.SetHistoryOptions(DefaultHistoryLimit, time.Hour)
+	form.lookupField("motd").SetValue("Welcome")
+	name := form.lookupField("name")
+	// Typing a character and deleting it again leaves no step behind
+	name.SetValue("l")
+	name.SetValue("")
+	if !form.Undo() {
+		t.Fatalf("the change of motd cannot be undone")
+	}
+	if values := form.GetAllFieldValues(); values["name"] != "" || values["motd"] != "" {
+		t.Errorf("values after one undo are %v, expected the change of motd to be undone", values)
+	}
+	if form.CanUndo() {
+		t.Errorf("an empty step is left after typing and deleting a character")
+	}
+	// Typing again starts a new step
+	form.Redo()
+	name.SetValue("s")
+	name.SetValue("sm")
+	if !form.Undo() || name.GetValue() != "" || form.GetAllFieldValues()["motd"] != "Welcome" {
+		t.Errorf("values after undoing the typing are %v", form.GetAllFieldValues())
+	}
+}
@@ -0,0 +1,190 @@
+package go_forms
+
+import (
+	"html/template"
+	"io"
+)
+
+// htmlTemplate renders the fields of a form as HTML form controls, the ids and names of the controls are the ids of the fields
+var htmlTemplate = template.Must(template.New("form").Parse(`<form class="go-forms" method="post">
+{{- with .Page}}
+<h2>{{.}}</h2>
+{{- end}}
+{{- template "fields" .Fields}}
+<button type="submit">{{.Submit}}</button>
+</form>
+{{define "fields"}}{{range .}}{{template "field" .}}{{end}}{{end}}
+{{- define "field"}}
+{{- if eq .Type "message"}}
+<p id="{{.Id}}">{{.Value}}</p>
+{{- else if eq .Type "tabs" "accordions"}}
+<div class="group-{{.Type}}">
+{{- template "fields" .Fields}}
+</div>
+{{- else if eq .Type "group"}}
+{{- if eq .Layout "accordion"}}
+<details id="{{.Id}}" class="group-accordion"{{if .Open}} open{{end}}>
+<summary>{{.Label}}</summary>
+{{- template "fields" .Fields}}
+</details>
+{{- else}}
+<fieldset id="{{.Id}}" class="group-{{.Layout}}"{{if eq .Layout "grid"}} data-columns="{{.Columns}}" style="--columns: {{.Columns}}"{{end}}>
+{{- with .Label}}
+<legend>{{.}}</legend>
+{{- end}}
+{{- template "fields" .Fields}}
+</fieldset>
+{{- end}}
+{{- else if eq .Type "choice"}}
+<fieldset id="{{.Id}}"{{if .Disabled}} disabled{{end}}>
+<legend>{{.Label}}{{if .Required}} *{{end}}</legend>
+{{- template "help" .}}
+{{- $field := .}}
+{{- range .Options}}
+<label><input type="radio" name="{{$field.Id}}" value="{{.Key}}"{{if .Checked}} checked{{end}}{{if $field.Required}} required{{end}}> {{.Label}}
+{{- with .Description}} <small>{{.}}</small>{{end}}</label>
+{{- end}}
+{{- template "error" .}}
+</fieldset>
+{{- else}}
+<label for="{{.Id}}">{{.Label}}{{if .Required}} *{{end}}</label>
+<input type="{{.Type}}" id="{{.Id}}" name="{{.Id}}" value="{{.Value}}"
+{{- with .Placeholder}} placeholder="{{.}}"{{end}}
+{{- if .Help}} aria-describedby="{{.Id}}-help"{{end}}
+{{- if .Required}} required{{end}}{{if .ReadOnly}} readonly{{end}}{{if .Disabled}} disabled{{end}}>
+{{- template "help" .}}
+{{- template "error" .}}
+{{- end}}
+{{- end}}
+{{- define "help"}}{{with .Help}}
+<p class="help" id="{{$.Id}}-help">{{.}}</p>
+{{- end}}{{end}}
+{{- define "error"}}{{with .Error}}
+<p class="error">{{.}}</p>
+{{- end}}{{end}}
+`))
+
+// htmlField is a field prepared for htmlTemplate, Type is message, group, choice or the type of the input.
+// Consecutive groups with the tab or accordion layout are wrapped in a field of the type tabs or accordions.
+type htmlField struct {
+	Type string
+	// Layout is the name of the layout of a group (see GroupLayout), Open is set for accordion sections containing an error
+	Layout      string
+	Columns     int
+	Open        bool
+	Id          string
+	Label       string
+	Value       string
+	Placeholder string
+	Help        string
+	Error       string
+	Required    bool
+	ReadOnly    bool
+	Disabled    bool
+	Options     []htmlOption
+	Fields      []htmlField
+}
+
+type htmlOption struct {
+	Key         string
+	Label       string
+	Description string
+	Checked     bool
+}
+
+// FormToHTML writes the displayed fields of the form (of the current page for wizards) as HTML form to w.
+// Fields are shown with their prompts, help texts and current values, options with their descriptions and errors like in
+// the other renderers once they should be shown (see ShouldShowError). The HTML is unstyled, the elements can be styled with CSS.
+// Groups are fieldsets with the class group-{layout}, accordion sections are details elements and consecutive tabs or accordion
+// sections are wrapped in a div with the class group-tabs or group-accordions. Grids set the custom property --columns.
+func FormToHTML(form *Form, w io.Writer) error {
+	data := struct {
+		Page   string
+		Submit string
+		Fields []htmlField
+	}{Submit: "Submit", Fields: htmlFields(form, form.GetFieldsToDisplay())}
+	if page := form.GetCurrentPage(); page != nil {
+		data.Page = form.GetProgressText() + ": " + page.GetTitle()
+		if !form.IsLastPage() {
+			data.Submit = "Next"
+		}
+	}
+	return htmlTemplate.Execute(w, data)
+}
+
+func htmlFields(form *Form, fields []Field) []htmlField {
+	var prepared []htmlField
+	for _, field := range fields {
+		base := getFieldBase(field)
+		if base == nil {
+			continue
+		}
+		htmlField := htmlField{
+			Type:     "text",
+			Id:       field.GetId(),
+			Label:    fieldLabel(field),
+			Value:    field.GetValue(),
+			Help:     base.GetHelpText(),
+			Required: base.IsRequired(),
+			ReadOnly: base.ReadOnly,
+			Disabled: base.IsDisabled(),
+		}
+		if form.ShouldShowError(field.GetId()) && !field.IsValid() && field.GetError() != nil {
+			htmlField.Error = field.GetError().Error()
+		}
+		switch field := field.(type) {
+		case *Message:
+			htmlField.Type = "message"
+		case *FieldGroup:
+			htmlField.Type = "group"
+			htmlField.Label = field.GetHeading()
+			htmlField.Layout = "list"
+			if name, ok := groupLayoutNames[field.Layout]; ok {
+				htmlField.Layout = name
+			}
+			htmlField.Columns = field.GetColumns()
+			htmlField.Fields = htmlFields(form, field.GetFieldsToDisplay())
+			if field.Layout == GroupLayoutTab || field.Layout == GroupLayoutAccordion {
+				// Tabs and accordion sections are titled with the id of groups without heading like in the other renderers
+				htmlField.Label = field.GetTitle()
+				htmlField.Open = containsHTMLError(htmlField.Fields)
+				prepared = appendHTMLSection(prepared, htmlField)
+				continue
+			}
+		case *MultipleChoiceField:
+			htmlField.Type = "choice"
+			for _, key := range sortedOptionKeys(field) {
+				option := field.Options[key]
+				htmlField.Options = append(htmlField.Options, htmlOption{Key: key, Label: option.Label, Description: option.Description, Checked: key == htmlField.Value})
+			}
+		case *NumberField:
+			htmlField.Type = "number"
+			htmlField.Placeholder = field.Placeholder
+		case *TextField:
+			htmlField.Placeholder = field.Placeholder
+		case *ComputedField:
+			htmlField.Placeholder = field.Placeholder
+		}
+		prepared = append(prepared, htmlField)
+	}
+	return prepared
+}
+
+// appendHTMLSection appends a tab or accordion section to the tabs or accordion before it or to a new one
+func appendHTMLSection(prepared []htmlField, section htmlField) []htmlField {
+	wrapper := section.Layout + "s"
+	if last := len(prepared) - 1; last >= 0 && prepared[last].Type == wrapper {
+		prepared[last].Fields = append(prepared[last].Fields, section)
+		return prepared
+	}
+	return append(prepared, htmlField{Type: wrapper, Fields: []htmlField{section}})
+}
+
+func containsHTMLError(fields []htmlField) bool {
+	for _, field := range fields {
+		if field.Error != "" || containsHTMLError(field.Fields) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package go_forms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormToHTML(t *testing.T) {
+	name := NewTextField("name", nil, []Validator{&MaxLengthValidator{MaxLength: 3}}, "lobby", "Name", "")
+	name.HelpText = "Shown in the <server list>"
+	name.Required = true
+	form := NewForm(
+		NewMessage("intro", nil, "Welcome"),
+		name,
+		NewMultipleChoiceField("software", nil, nil, "", "Software", map[string]Option{
+			"paper":   {Label: "Paper", Description: "Fast and plugin friendly"},
+			"vanilla": {Label: "Vanilla"},
+		}, "paper"),
+		NewFieldGroup("network", nil, nil, "Network", NewNumberField("port", nil, nil, "", "Port", 25565)),
+		NewTextField("hidden", []DisplayCondition{&HasValueDisplayCondition{FieldId: "name", Value: "admin"}}, nil, "", "Hidden", ""),
+	)
+	name.SetValue("survival")
+	form.MarkTouched("name")
+	var out strings.Builder
+	if err := FormToHTML(form, &out); err != nil {
+		t.Fatalf("FormToHTML returned %v", err)
+	}
+	html := out.String()
+	for _, expected := range []string{
+		`<p id="intro">Welcome</p>`,
+		`<label for="name">Name *</label>`,
+		`<input type="text" id="name" name="name" value="survival" placeholder="lobby" aria-describedby="name-help" required>`,
+		`<p class="help" id="name-help">Shown in the &lt;server list&gt;</p>`,
+		`<p class="error">`,
+		`<input type="radio" name="software" value="paper" checked> Paper <small>Fast and plugin friendly</small></label>`,
+		`<input type="radio" name="software" value="vanilla"> Vanilla</label>`,
+		`<legend>Network</legend>`,
+		`<input type="number" id="port" name="port" value="25565">`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("HTML does not contain %s:\n%s", expected, html)
+		}
+	}
+	if strings.Contains(html, `id="hidden"`) {
+		t.Errorf("HTML contains the hidden field:\n%s", html)
+	}
+}
+
+func TestFormToHTMLGroupLayouts(t *testing.T) {
+	port := NewNumberField("port", nil, []Validator{&PortValidator{}}, "", "Port", 0)
+	port.SetValue("70000")
+	form := NewForm(
+		NewFieldGroup("general", nil, nil, "General", NewTextField("name", nil, nil, "", "Name", "")),
+		newLayoutGroup("server", GroupLayoutCard, 0, "Server", NewTextField("motd", nil, nil, "", "Message of the day", "")),
+		newLayoutGroup("world", GroupLayoutTab, 0, "World", NewTextField("seed", nil, nil, "", "Seed", "")),
+		newLayoutGroup("players", GroupLayoutTab, 0, "", NewNumberField("slots", nil, nil, "", "Slots", 20)),
+		newLayoutGroup("network", GroupLayoutAccordion, 0, "Network", port),
+		newLayoutGroup("advanced", GroupLayoutAccordion, 0, "Advanced", NewTextField("flags", nil, nil, "", "Flags", "")),
+		newLayoutGroup("resources", GroupLayoutGrid, 3, "Resources", NewNumberField("ram", nil, nil, "", "RAM", 2048)),
+	)
+	form.MarkTouched("port")
+	var out strings.Builder
+	if err := FormToHTML(form, &out); err != nil {
+		t.Fatalf("FormToHTML returned %v", err)
+	}
+	html := out.String()
+	for _, expected := range []string{
+		`<fieldset id="general" class="group-list">` + "\n<legend>General</legend>",
+		`<fieldset id="server" class="group-card">` + "\n<legend>Server</legend>",
+		`<div class="group-tabs">` + "\n" + `<fieldset id="world" class="group-tab">` + "\n<legend>World</legend>",
+		// Tabs without heading are titled with their id
+		`<fieldset id="players" class="group-tab">` + "\n<legend>players</legend>",
+		`<div class="group-accordions">` + "\n" + `<details id="network" class="group-accordion" open>` + "\n<summary>Network</summary>",
+		`<details id="advanced" class="group-accordion">` + "\n<summary>Advanced</summary>",
+		`<fieldset id="resources" class="group-grid" data-columns="3" style="--columns: 3">`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("HTML does not contain %s:\n%s", expected, html)
+		}
+	}
+	// Consecutive tabs and accordion sections share one wrapper
+	if strings.Count(html, `<div class="group-tabs">`) != 1 || strings.Count(html, `<div class="group-accordions">`) != 1 {
+		t.Errorf("HTML does not wrap the consecutive tabs and accordion sections once:\n%s", html)
+	}
+}
+
+func newLayoutGroup(id string, layout GroupLayout, columns int, heading string, fields ...Field) *FieldGroup {
+	group := NewFieldGroup(id, nil, nil, heading, fields...)
+	group.Layout = layout
+	group.Columns = columns
+	return group
+}
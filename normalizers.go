@@ -0,0 +1,68 @@
+package go_forms
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizer transforms a value before it is stored in a field.
+// The normalizers of a field are applied in SetValue in the given order, so validators only see normalized values.
+type Normalizer interface {
+	Normalize(value string) string
+}
+
+type CustomNormalizer struct {
+	Normalizer func(value string) string
+}
+
+func (n *CustomNormalizer) Normalize(value string) string {
+	return n.Normalizer(value)
+}
+
+// TrimNormalizer removes leading and trailing whitespace
+type TrimNormalizer struct{}
+
+func (n *TrimNormalizer) Normalize(value string) string {
+	return strings.TrimSpace(value)
+}
+
+// CollapseWhitespaceNormalizer replaces every sequence of whitespace characters with a single space
+type CollapseWhitespaceNormalizer struct{}
+
+func (n *CollapseWhitespaceNormalizer) Normalize(value string) string {
+	var builder strings.Builder
+	inWhitespace := false
+	for _, r := range value {
+		if unicode.IsSpace(r) {
+			if !inWhitespace {
+				builder.WriteRune(' ')
+			}
+			inWhitespace = true
+			continue
+		}
+		inWhitespace = false
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// NfcNormalizer converts the value to the unicode normalization form C, e.g. "e" followed by a combining accent becomes "é"
+type NfcNormalizer struct{}
+
+func (n *NfcNormalizer) Normalize(value string) string {
+	return norm.NFC.String(value)
+}
+
+type LowerCaseNormalizer struct{}
+
+func (n *LowerCaseNormalizer) Normalize(value string) string {
+	return strings.ToLower(value)
+}
+
+type UpperCaseNormalizer struct{}
+
+func (n *UpperCaseNormalizer) Normalize(value string) string {
+	return strings.ToUpper(value)
+}
@@ -0,0 +1,113 @@
+package go_forms
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLengthUnits(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		// runes, graphemes and bytes are the lengths in the units
+		runes     int
+		graphemes int
+		bytes     int
+	}{
+		{name: "ascii", value: "lobby", runes: 5, graphemes: 5, bytes: 5},
+		{name: "umlaut", value: "Größe", runes: 5, graphemes: 5, bytes: 7},
+		{name: "combining accent", value: "cafe\u0301", runes: 5, graphemes: 4, bytes: 6},
+		{name: "flag emoji", value: "🇩🇪", runes: 2, graphemes: 1, bytes: 8},
+		{name: "family emoji", value: "👨‍👩‍👧", runes: 5, graphemes: 1, bytes: 18},
+		{name: "empty", value: "", runes: 0, graphemes: 0, bytes: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for unit, expected := range map[LengthUnit]int{LengthInRunes: test.runes, LengthInGraphemes: test.graphemes, LengthInBytes: test.bytes} {
+				if length := unit.Length(test.value); length != expected {
+					t.Errorf("length of %q in unit %d is %d, expected %d", test.value, unit, length, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestLengthValidatorUnits(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator Validator
+		value     string
+		valid     bool
+	}{
+		// Runes are the default unit
+		{name: "max length in runes", validator: &MaxLengthValidator{MaxLength: 4}, value: "cafe\u0301", valid: false},
+		{name: "max length in graphemes", validator: &MaxLengthValidator{MaxLength: 4, Unit: LengthInGraphemes}, value: "cafe\u0301", valid: true},
+		{name: "max length in bytes", validator: &MaxLengthValidator{MaxLength: 5, Unit: LengthInBytes}, value: "Größe", valid: false},
+		{name: "min length in runes", validator: &MinLengthValidator{MinLength: 2}, value: "🇩🇪", valid: true},
+		{name: "min length in graphemes", validator: &MinLengthValidator{MinLength: 2, Unit: LengthInGraphemes}, value: "🇩🇪", valid: false},
+		{name: "min length in bytes", validator: &MinLengthValidator{MinLength: 8, Unit: LengthInBytes}, value: "🇩🇪", valid: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			field := NewTextField("value", nil, nil, "", "Value", test.value)
+			if valid := test.validator.Validate(field); valid != test.valid {
+				t.Errorf("%q is valid: %v, expected %v (error: %v)", test.value, valid, test.valid, field.GetError())
+			}
+		})
+	}
+}
+
+func TestNormalizers(t *testing.T) {
+	tests := []struct {
+		name       string
+		normalizer Normalizer
+		value      string
+		expected   string
+	}{
+		{name: "trim", normalizer: &TrimNormalizer{}, value: " \t lobby \n", expected: "lobby"},
+		{name: "trim keeps inner spaces", normalizer: &TrimNormalizer{}, value: " my  lobby ", expected: "my  lobby"},
+		{name: "collapse whitespace", normalizer: &CollapseWhitespaceNormalizer{}, value: "my \t lobby\n\nserver", expected: "my lobby server"},
+		{name: "collapse keeps one leading and trailing space", normalizer: &CollapseWhitespaceNormalizer{}, value: "  lobby  ", expected: " lobby "},
+		{name: "lower case", normalizer: &LowerCaseNormalizer{}, value: "Größe LOBBY", expected: "größe lobby"},
+		{name: "upper case", normalizer: &UpperCaseNormalizer{}, value: "Größe lobby", expected: "GRÖßE LOBBY"},
+		{name: "nfc", normalizer: &NfcNormalizer{}, value: "cafe\u0301", expected: "café"},
+		{name: "custom", normalizer: &CustomNormalizer{Normalizer: func(value string) string { return value + "!" }}, value: "lobby", expected: "lobby!"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if normalized := test.normalizer.Normalize(test.value); normalized != test.expected {
+				t.Errorf("%q is normalized to %q, expected %q", test.value, normalized, test.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizersRunInOrderBeforeValidators(t *testing.T) {
+	var steps []string
+	step := func(name string) *CustomNormalizer {
+		return &CustomNormalizer{Normalizer: func(value string) string {
+			steps = append(steps, name+":"+value)
+			return value
+		}}
+	}
+	validator := &CustomValidator{Validator: func(field any) (bool, error) {
+		steps = append(steps, "validate:"+getFieldBase(field).GetValue())
+		return true, nil
+	}}
+	name := NewTextField("name", nil, []Validator{validator}, "", "Name", "")
+	name.Normalizers = []Normalizer{step("first"), &TrimNormalizer{}, step("second"), &CollapseWhitespaceNormalizer{}, step("third")}
+	NewForm(name)
+	steps = nil
+	name.SetValue("  my   lobby ")
+	name.IsValid()
+	expected := []string{"first:  my   lobby ", "second:my   lobby", "third:my lobby", "validate:my lobby"}
+	if !slices.Equal(steps, expected) {
+		t.Errorf("steps are %q, expected %q", steps, expected)
+	}
+	// Validators only see normalized values, so a max length counts the collapsed value
+	name.Validators = []Validator{&MaxLengthValidator{MaxLength: 8}}
+	name.SetValue("  my \t lobby  ")
+	if !name.IsValid() || name.GetValue() != "my lobby" {
+		t.Errorf("value is %q and valid: %v, expected the valid value %q", name.GetValue(), name.IsValid(), "my lobby")
+	}
+}
@@ -0,0 +1,139 @@
+package go_forms
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+)
+
+// Values are the values of a submitted form by field id like GetFieldValues returns them, groups have the values of their fields as JSON
+type Values map[string]string
+
+// String returns the value of the field with the given id or an empty string if the form has no such field
+func (v Values) String(id string) string {
+	return v[id]
+}
+
+// Int converts the value of the field with the given id to an int
+func (v Values) Int(id string) (int, error) {
+	value, err := v.lookup(id)
+	if err != nil {
+		return 0, err
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &CustomError{Code: ErrorCodeInteger, Message: "Value is not an integer (field: " + id + ", value: " + value + ")"}
+	}
+	return number, nil
+}
+
+// Float converts the value of the field with the given id to a float64
+func (v Values) Float(id string) (float64, error) {
+	value, err := v.lookup(id)
+	if err != nil {
+		return 0, err
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, &CustomError{Message: "Value is not a number (field: " + id + ", value: " + value + ")"}
+	}
+	return number, nil
+}
+
+// Bool converts the value of the field with the given id to a bool, see strconv.ParseBool for the accepted values
+func (v Values) Bool(id string) (bool, error) {
+	value, err := v.lookup(id)
+	if err != nil {
+		return false, err
+	}
+	boolean, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, &CustomError{Message: "Value is not a boolean (field: " + id + ", value: " + value + ")"}
+	}
+	return boolean, nil
+}
+
+func (v Values) lookup(id string) (string, error) {
+	value, ok := v[id]
+	if !ok {
+		return "", &CustomError{Message: "Form has no field " + id}
+	}
+	return value, nil
+}
+
+// PromptResult is the result of a prompt, Err is ErrCancelled if the user cancelled the form or the error of the context
+type PromptResult struct {
+	Values Values
+	Err    error
+}
+
+// PromptFyne shows the form in a popup and blocks until the user submits or cancels it or the context ends, which closes the popup.
+// It returns ErrCancelled if the user cancelled the form and the error of the context if it ended.
+// PromptFyne must not be called from Fyne callbacks or the callbacks of a FyneForm because they run on the goroutines handling the input
+// and the updates of the popup.
+func PromptFyne(ctx context.Context, title string, form *Form, window fyne.Window) (Values, error) {
+	result := <-PromptFyneAsync(ctx, title, form, window)
+	return result.Values, result.Err
+}
+
+// PromptFyneAsync shows the form in a popup like PromptFyne and returns a channel receiving the result once
+func PromptFyneAsync(ctx context.Context, title string, form *Form, window fyne.Window) <-chan PromptResult {
+	results := make(chan PromptResult, 1)
+	if err := ctx.Err(); err != nil {
+		results <- PromptResult{Err: err}
+		close(results)
+		return results
+	}
+	formWidget := NewFyneForm(form, window)
+	formPopup := dialog.NewCustomWithoutButtons(title, formWidget, window)
+	formPopup.Resize(fyne.NewSize(700, 400))
+
+	// answers receives the first answer of the user, later ones (e.g. the close after a submit) are dropped
+	answers := make(chan PromptResult, 1)
+	answer := func(result PromptResult) {
+		select {
+		case answers <- result:
+		default:
+		}
+	}
+	formWidget.OnSubmit = func(values map[string]string) {
+		answer(PromptResult{Values: Values(values)})
+	}
+	formWidget.OnCancel = func() {
+		answer(PromptResult{Err: ErrCancelled})
+	}
+	formPopup.SetOnClosed(func() {
+		formWidget.Destroy()
+		answer(PromptResult{Err: ErrCancelled})
+	})
+	formPopup.Show()
+	go func() {
+		var result PromptResult
+		select {
+		case result = <-answers:
+		case <-ctx.Done():
+			result = PromptResult{Err: ctx.Err()}
+		}
+		// The popup is hidden by the goroutine updating the widgets of the form
+		formWidget.renderer.updates.run(func() {
+			formPopup.Hide()
+			results <- result
+			close(results)
+		})
+	}()
+	return results
+}
+
+// PromptTerminal asks for the values of the form line by line like FormToTerminal and returns them once the form is valid.
+// It returns ErrCancelled at the end of the input and the error of the context if it ends while waiting for input.
+// Pass the same *bufio.Reader to prompts asked one after another, otherwise buffered input may be lost.
+func PromptTerminal(ctx context.Context, form *Form, in io.Reader, out io.Writer) (Values, error) {
+	values, err := newTerminalRenderer(form, in, out).run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Values(values), nil
+}
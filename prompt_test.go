@@ -0,0 +1,27 @@
+package go_forms
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestPromptTerminalReturnsTheSubmittedValues(t *testing.T) {
+	form := NewForm(
+		NewMultipleChoiceField("mode", nil, nil, "", "Mode", map[string]Option{"simple": {Label: "Simple"}, "advanced": {Label: "Advanced"}}, "simple"),
+		NewTextField("extra", []DisplayCondition{&HasValueDisplayCondition{FieldId: "mode", Value: "advanced"}}, nil, "", "Extra", "hidden"),
+		NewFieldGroup("network", nil, nil, "Network", NewNumberField("port", nil, nil, "", "Port", 25565)),
+	)
+	form.SetHiddenFieldPolicy(ExcludeHiddenValues)
+	var out bytes.Buffer
+	values, err := PromptTerminal(context.Background(), form, strings.NewReader("\n\n"), &out)
+	if err != nil {
+		t.Fatalf("PromptTerminal returned %v (output: %s)", err, out.String())
+	}
+	expected := Values{"mode": "simple", "network": `{"port":"25565"}`}
+	if !maps.Equal(values, expected) {
+		t.Errorf("values are %v, expected %v", values, expected)
+	}
+}
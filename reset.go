@@ -0,0 +1,88 @@
+package go_forms
+
+// Defining the reset functions of the Form Type
+
+// Reset sets all fields to their defaults (see FieldBaseType.GetDefault), marks them as untouched and shows the first wizard page.
+// The reset is one undo step, the listeners are notified like for any other change.
+func (f *Form) Reset() {
+	f.resetFields(f.GetAllFields(), f.findPage(0, 1), func(base *FieldBaseType, values map[string]string) (string, bool) {
+		return base.defaultFrom(values), true
+	})
+}
+
+// ResetField sets the field with the given id (or the fields nested in the group with the given id) to its default and marks it as untouched
+func (f *Form) ResetField(id string) error {
+	field := f.lookupField(id)
+	if field == nil {
+		return &CustomError{Message: "Unknown field (id: " + id + ")"}
+	}
+	f.resetFields(flattenFields([]Field{field}), -1, func(base *FieldBaseType, values map[string]string) (string, bool) {
+		return base.defaultFrom(values), true
+	})
+	return nil
+}
+
+// Clear empties all fields except read-only ones and marks them as untouched, computed fields are computed from the empty values.
+// Like Reset it is one undo step.
+func (f *Form) Clear() {
+	f.resetFields(f.GetAllFields(), -1, func(base *FieldBaseType, _ map[string]string) (string, bool) {
+		return "", !base.IsReadOnly()
+	})
+}
+
+// resetFields stores the values returned by value for the given fields in one undo step, marks the fields as untouched and propagates
+// the change. Messages, groups and computed fields are skipped, the computed fields are updated afterwards. The wizard switches to the
+// page with the given index unless it is -1.
+// The values are determined one after another before the form is locked, value gets the values of the form with the fields before
+// already reset, so a DefaultFunc sees the values reset before its field.
+func (f *Form) resetFields(fields []Field, page int, value func(base *FieldBaseType, values map[string]string) (string, bool)) {
+	values := f.getAllFieldValues()
+	var bases []*FieldBaseType
+	var newValues []string
+	for _, field := range fields {
+		switch field.(type) {
+		case *Message, *FieldGroup, *ComputedField:
+			continue
+		}
+		base := getFieldBase(field)
+		if base == nil {
+			continue
+		}
+		newValue, ok := value(base, values)
+		if !ok {
+			continue
+		}
+		values[base.Id] = newValue
+		bases = append(bases, base)
+		newValues = append(newValues, newValue)
+	}
+	f.stateMu.Lock()
+	for _, field := range fields {
+		delete(f.touched, field.GetId())
+	}
+	f.stateMu.Unlock()
+	var changes []valueChange
+	var changedIds []string
+	f.mu.Lock()
+	if page != -1 {
+		// The draft is saved once when the reset is propagated
+		f.storeCurrentPageIndex(page)
+	}
+	for index, base := range bases {
+		if oldValue := base.storeValue(newValues[index]); oldValue != newValues[index] {
+			changes = append(changes, valueChange{fieldId: base.Id, oldValue: oldValue, newValue: newValues[index]})
+			changedIds = append(changedIds, base.Id)
+		}
+	}
+	step := f.history.record(changes)
+	f.propagate(pendingChange{ids: changedIds, recomputeAll: true, step: step})
+}
+
+// applyDynamicDefaults sets the fields with a DefaultFunc to their default in the order of the form, it is called when the form is created
+func (f *Form) applyDynamicDefaults() {
+	for _, field := range f.GetAllFields() {
+		if base := getFieldBase(field); base != nil && base.DefaultFunc != nil {
+			base.storeValue(base.GetDefault())
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package go_forms
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func newResetWizard() *Form {
+	name := NewTextField("name", nil, nil, "", "Name", "lobby")
+	slug := NewTextField("slug", nil, nil, "", "Slug", "")
+	slug.DefaultFunc = func(values map[string]string) string {
+		return values["name"] + "-slug"
+	}
+	id := NewTextField("id", nil, nil, "", "Id", "42")
+	id.ReadOnly = true
+	return NewWizard(
+		NewWizardPage("general", "General", nil, name, slug, id),
+		NewWizardPage("network", "Network", nil, NewFieldGroup("ports", nil, nil, "Ports",
+			NewNumberField("port", nil, nil, "", "Port", 25565),
+			NewNumberField("query", nil, nil, "", "Query port", 25566),
+		)),
+	)
+}
+
+func TestReset(t *testing.T) {
+	tests := []struct {
+		name   string
+		reset  func(form *Form)
+		values map[string]string
+		page   string
+		// touched are the fields that are still touched
+		touched []string
+	}{
+		{
+			name:   "reset",
+			reset:  (*Form).Reset,
+			values: map[string]string{"name": "lobby", "slug": "lobby-slug", "id": "42", "port": "25565", "query": "25566"},
+			page:   "general",
+		},
+		{
+			name: "reset a group",
+			reset: func(form *Form) {
+				if err := form.ResetField("ports"); err != nil {
+					t.Fatal(err)
+				}
+			},
+			values:  map[string]string{"name": "survival", "slug": "custom", "id": "42", "port": "25565", "query": "25566"},
+			page:    "network",
+			touched: []string{"name", "slug"},
+		},
+		{
+			name:   "clear",
+			reset:  (*Form).Clear,
+			values: map[string]string{"name": "", "slug": "", "id": "42", "port": "", "query": ""},
+			page:   "network",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			form := newResetWizard()
+			for id, value := range map[string]string{"name": "survival", "slug": "custom", "port": "1", "query": "2"} {
+				form.lookupField(id).SetValue(value)
+				form.MarkTouched(id)
+			}
+			form.SetCurrentPage("network")
+			test.reset(form)
+			if values := form.GetAllFieldValues(); !maps.Equal(values, test.values) {
+				t.Errorf("values are %v, expected %v", values, test.values)
+			}
+			if page := form.GetCurrentPage().Id; page != test.page {
+				t.Errorf("page is %s, expected %s", page, test.page)
+			}
+			for _, id := range []string{"name", "slug", "port", "query"} {
+				if touched := slices.Contains(test.touched, id); form.IsTouched(id) != touched {
+					t.Errorf("%s is touched: %v, expected %v", id, form.IsTouched(id), touched)
+				}
+			}
+			if !form.Undo() || form.lookupField("port").GetValue() != "1" {
+				t.Errorf("the reset cannot be undone in one step")
+			}
+		})
+	}
+	if err := newResetWizard().ResetField("motd"); err == nil {
+		t.Errorf("ResetField accepted an unknown field")
+	}
+}
@@ -0,0 +1,643 @@
+package go_forms
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SchemaVersion is the version of the schema format read by ParseSchema
+const SchemaVersion = 1
+
+// Schema describes a form as data, e.g. loaded from a JSON file.
+// A schema either has Fields (a normal form) or Pages (a wizard).
+type Schema struct {
+	Version int    `json:"version"`
+	Title   string `json:"title,omitempty"`
+	// ValidationMode is one of change (the default), blur and submit (see ValidationMode)
+	ValidationMode string `json:"validation_mode,omitempty"`
+	// HiddenValues is one of keep (the default), exclude, clear and reset (see HiddenFieldPolicy)
+	HiddenValues string        `json:"hidden_values,omitempty"`
+	Fields       []FieldSchema `json:"fields,omitempty"`
+	Pages        []PageSchema  `json:"pages,omitempty"`
+}
+
+type PageSchema struct {
+	Id                string            `json:"id"`
+	Title             string            `json:"title,omitempty"`
+	DisplayConditions []ConditionSchema `json:"display_conditions,omitempty"`
+	Fields            []FieldSchema     `json:"fields"`
+}
+
+// FieldSchema describes a field. Type is one of text, number, choice, message and group.
+type FieldSchema struct {
+	Type              string            `json:"type"`
+	Id                string            `json:"id"`
+	Prompt            string            `json:"prompt,omitempty"`
+	Placeholder       string            `json:"placeholder,omitempty"`
+	Help              string            `json:"help,omitempty"`
+	Default           string            `json:"default,omitempty"`
+	DefaultEnv        string            `json:"default_env,omitempty"`
+	Message           string            `json:"message,omitempty"`
+	Heading           string            `json:"heading,omitempty"`
+	Layout            string            `json:"layout,omitempty"`
+	Columns           int               `json:"columns,omitempty"`
+	Options           []OptionSchema    `json:"options,omitempty"`
+	Required          bool              `json:"required,omitempty"`
+	RequiredIf        []ConditionSchema `json:"required_if,omitempty"`
+	ReadOnly          bool              `json:"read_only,omitempty"`
+	DisableConditions []ConditionSchema `json:"disable_conditions,omitempty"`
+	ValidateDisabled  bool              `json:"validate_disabled,omitempty"`
+	Validators        []ValidatorSchema `json:"validators,omitempty"`
+	DisplayConditions []ConditionSchema `json:"display_conditions,omitempty"`
+	Normalizers       []string          `json:"normalizers,omitempty"`
+	Fields            []FieldSchema     `json:"fields,omitempty"`
+}
+
+type OptionSchema struct {
+	Key         string `json:"key"`
+	Label       string `json:"label"`
+	Description string `json:"description,omitempty"`
+}
+
+// ValidatorSchema describes a built-in validator, Type is the error code of the validator (e.g. not_empty or max_length).
+// The other properties are used depending on the type, see the README for the list of validators.
+type ValidatorSchema struct {
+	Type    string   `json:"type"`
+	Min     int      `json:"min,omitempty"`
+	Max     int      `json:"max,omitempty"`
+	Unit    string   `json:"unit,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+	Value   string   `json:"value,omitempty"`
+	Values  []string `json:"values,omitempty"`
+	Field   string   `json:"field,omitempty"`
+	Fields  []string `json:"fields,omitempty"`
+	Layout  string   `json:"layout,omitempty"`
+	// Expression and Message are used by the expression validator, Message also by the not validator
+	Expression string `json:"expression,omitempty"`
+	Message    string `json:"message,omitempty"`
+	// Validators and Condition are used by the not, any_of, all_of and when validators
+	Validators []ValidatorSchema `json:"validators,omitempty"`
+	Condition  *ConditionSchema  `json:"condition,omitempty"`
+}
+
+// ConditionSchema describes a built-in display condition.
+// Type is one of always, has_value, is_valid, is_invalid, all_fields_valid, display_after, and, or, not and expression.
+type ConditionSchema struct {
+	Type       string            `json:"type"`
+	Field      string            `json:"field,omitempty"`
+	Fields     []string          `json:"fields,omitempty"`
+	Value      string            `json:"value,omitempty"`
+	Conditions []ConditionSchema `json:"conditions,omitempty"`
+	Condition  *ConditionSchema  `json:"condition,omitempty"`
+	Expression string            `json:"expression,omitempty"`
+}
+
+// SchemaError is returned if a schema is invalid, Path points to the invalid part like "fields[2].validators[0]"
+type SchemaError struct {
+	Path    string
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	if e.Path == "" {
+		return "Invalid schema: " + e.Message
+	}
+	return "Invalid schema at " + e.Path + ": " + e.Message
+}
+
+// ParseSchema decodes a JSON schema and checks its version
+func ParseSchema(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	if schema.Version == 0 {
+		schema.Version = SchemaVersion
+	}
+	if schema.Version != SchemaVersion {
+		return nil, &SchemaError{Message: "unsupported version (version: " + strconv.Itoa(schema.Version) + ", supported version: " + strconv.Itoa(SchemaVersion) + ")"}
+	}
+	return &schema, nil
+}
+
+// LoadSchema decodes a JSON schema and builds the form described by it
+func LoadSchema(data []byte) (*Form, error) {
+	schema, err := ParseSchema(data)
+	if err != nil {
+		return nil, err
+	}
+	return schema.NewForm()
+}
+
+// LoadSchemaFile reads a JSON schema from a file and builds the form described by it
+func LoadSchemaFile(path string) (*Form, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadSchema(data)
+}
+
+// schemaBuilder keeps the state needed to build a form from a schema
+type schemaBuilder struct {
+	ids         map[string]bool
+	references  []schemaReference
+	expressions []schemaExpression
+}
+
+// schemaReference is a field id used by a validator or display condition, it is checked after all fields are known
+type schemaReference struct {
+	path string
+	id   string
+}
+
+// schemaExpression is an expression used by a validator or display condition, it is type checked after the form is built
+type schemaExpression struct {
+	path       string
+	expression *Expression
+}
+
+// NewForm builds the form described by the schema.
+// It returns a *SchemaError if a field type, validator, display condition or normalizer is unknown, an id is used twice,
+// a referenced field does not exist, an expression is invalid or expressions depend on each other.
+func (s *Schema) NewForm() (*Form, error) {
+	if len(s.Fields) > 0 && len(s.Pages) > 0 {
+		return nil, &SchemaError{Message: "a schema cannot have fields and pages"}
+	}
+	validationMode, err := parseValidationMode("validation_mode", s.ValidationMode)
+	if err != nil {
+		return nil, err
+	}
+	hiddenFieldPolicy, err := parseHiddenFieldPolicy("hidden_values", s.HiddenValues)
+	if err != nil {
+		return nil, err
+	}
+	builder := &schemaBuilder{ids: make(map[string]bool)}
+	var form *Form
+	if len(s.Pages) > 0 {
+		pages := make([]*WizardPage, 0, len(s.Pages))
+		for index, pageSchema := range s.Pages {
+			path := "pages[" + strconv.Itoa(index) + "]"
+			page, err := builder.buildPage(path, pageSchema)
+			if err != nil {
+				return nil, err
+			}
+			pages = append(pages, page)
+		}
+		form = NewWizard(pages...)
+	} else {
+		fields, err := builder.buildFields("fields", s.Fields)
+		if err != nil {
+			return nil, err
+		}
+		form = NewForm(fields...)
+	}
+	for _, reference := range builder.references {
+		if !builder.ids[reference.id] {
+			return nil, &SchemaError{Path: reference.path, Message: "unknown field (id: " + reference.id + ")"}
+		}
+	}
+	for _, expression := range builder.expressions {
+		if err := expression.expression.Check(form); err != nil {
+			return nil, &SchemaError{Path: expression.path, Message: err.Error()}
+		}
+	}
+	// Expressions depending on each other are only found in the built form, they have to be rejected before their first evaluation
+	if err := form.CheckExpressions(); err != nil {
+		return nil, &SchemaError{Path: builder.expressionPath(err), Message: err.Error()}
+	}
+	form.SetValidationMode(validationMode)
+	form.SetHiddenFieldPolicy(hiddenFieldPolicy)
+	return form, nil
+}
+
+func (b *schemaBuilder) buildPage(path string, pageSchema PageSchema) (*WizardPage, error) {
+	if pageSchema.Id == "" {
+		return nil, &SchemaError{Path: path, Message: "missing id"}
+	}
+	displayConditions, err := b.buildConditions(path+".display_conditions", pageSchema.DisplayConditions)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := b.buildFields(path+".fields", pageSchema.Fields)
+	if err != nil {
+		return nil, err
+	}
+	return NewWizardPage(pageSchema.Id, pageSchema.Title, displayConditions, fields...), nil
+}
+
+func (b *schemaBuilder) buildFields(path string, fieldSchemas []FieldSchema) ([]Field, error) {
+	fields := make([]Field, 0, len(fieldSchemas))
+	for index, fieldSchema := range fieldSchemas {
+		field, err := b.buildField(path+"["+strconv.Itoa(index)+"]", fieldSchema)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func (b *schemaBuilder) buildField(path string, fieldSchema FieldSchema) (Field, error) {
+	if fieldSchema.Id == "" {
+		return nil, &SchemaError{Path: path, Message: "missing id"}
+	}
+	if b.ids[fieldSchema.Id] {
+		return nil, &SchemaError{Path: path, Message: "duplicate id (id: " + fieldSchema.Id + ")"}
+	}
+	b.ids[fieldSchema.Id] = true
+	displayConditions, err := b.buildConditions(path+".display_conditions", fieldSchema.DisplayConditions)
+	if err != nil {
+		return nil, err
+	}
+	requiredIf, err := b.buildConditions(path+".required_if", fieldSchema.RequiredIf)
+	if err != nil {
+		return nil, err
+	}
+	disableConditions, err := b.buildConditions(path+".disable_conditions", fieldSchema.DisableConditions)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := b.buildValidators(path+".validators", fieldSchema.Validators)
+	if err != nil {
+		return nil, err
+	}
+	normalizers, err := buildNormalizers(path+".normalizers", fieldSchema.Normalizers)
+	if err != nil {
+		return nil, err
+	}
+	var field Field
+	switch fieldSchema.Type {
+	case "text":
+		field = NewTextField(fieldSchema.Id, displayConditions, validators, fieldSchema.Placeholder, fieldSchema.Prompt, fieldSchema.Default)
+	case "number":
+		defaultValue := 0
+		if fieldSchema.Default != "" {
+			if defaultValue, err = strconv.Atoi(fieldSchema.Default); err != nil {
+				return nil, &SchemaError{Path: path + ".default", Message: "default value is not an integer (value: " + fieldSchema.Default + ")"}
+			}
+		}
+		field = NewNumberField(fieldSchema.Id, displayConditions, validators, fieldSchema.Placeholder, fieldSchema.Prompt, defaultValue)
+	case "choice":
+		options := make(map[string]Option, len(fieldSchema.Options))
+		for _, option := range fieldSchema.Options {
+			options[option.Key] = Option{Label: option.Label, Description: option.Description}
+		}
+		field = NewMultipleChoiceField(fieldSchema.Id, displayConditions, validators, fieldSchema.Placeholder, fieldSchema.Prompt, options, fieldSchema.Default)
+	case "message":
+		field = NewMessage(fieldSchema.Id, displayConditions, fieldSchema.Message)
+	case "group":
+		children, err := b.buildFields(path+".fields", fieldSchema.Fields)
+		if err != nil {
+			return nil, err
+		}
+		layout, err := parseGroupLayout(path+".layout", fieldSchema.Layout)
+		if err != nil {
+			return nil, err
+		}
+		group := NewFieldGroup(fieldSchema.Id, displayConditions, validators, fieldSchema.Heading, children...)
+		group.Layout = layout
+		group.Columns = fieldSchema.Columns
+		field = group
+	default:
+		return nil, &SchemaError{Path: path + ".type", Message: "unknown field type (type: " + fieldSchema.Type + ")"}
+	}
+	base := getFieldBase(field)
+	base.Normalizers = normalizers
+	base.HelpText = fieldSchema.Help
+	base.Required = fieldSchema.Required
+	base.RequiredIf = requiredIf
+	base.ReadOnly = fieldSchema.ReadOnly
+	base.DisableConditions = disableConditions
+	if fieldSchema.ValidateDisabled {
+		base.DisabledValidation = ValidateDisabled
+	}
+	if fieldSchema.DefaultEnv != "" {
+		// The environment variable overrides the default if it is set, it is read again whenever the field is reset
+		name, staticDefault := fieldSchema.DefaultEnv, base.Default
+		base.DefaultFunc = func(_ map[string]string) string {
+			if value, ok := os.LookupEnv(name); ok {
+				return value
+			}
+			return staticDefault
+		}
+		base.defaultEnv = name
+	}
+	return field, nil
+}
+
+func (b *schemaBuilder) buildValidators(path string, validatorSchemas []ValidatorSchema) ([]Validator, error) {
+	validators := make([]Validator, 0, len(validatorSchemas))
+	for index, validatorSchema := range validatorSchemas {
+		validator, err := b.buildValidator(path+"["+strconv.Itoa(index)+"]", validatorSchema)
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, validator)
+	}
+	return validators, nil
+}
+
+func (b *schemaBuilder) buildValidator(path string, v ValidatorSchema) (Validator, error) {
+	switch v.Type {
+	case ErrorCodeNotEmpty:
+		return &NotEmptyValidator{}, nil
+	case ErrorCodeMinLength, ErrorCodeMaxLength:
+		unit, err := parseLengthUnit(path+".unit", v.Unit)
+		if err != nil {
+			return nil, err
+		}
+		if v.Type == ErrorCodeMinLength {
+			return &MinLengthValidator{MinLength: v.Min, Unit: unit}, nil
+		}
+		return &MaxLengthValidator{MaxLength: v.Max, Unit: unit}, nil
+	case ErrorCodeIp:
+		return &IpValidator{}, nil
+	case ErrorCodeIpv4:
+		return &Ipv4Validator{}, nil
+	case ErrorCodeIpv6:
+		return &Ipv6Validator{}, nil
+	case ErrorCodeRegex:
+		if _, err := regexp.Compile(v.Pattern); err != nil {
+			return nil, &SchemaError{Path: path + ".pattern", Message: err.Error()}
+		}
+		return &RegexValidator{RegexPattern: v.Pattern}, nil
+	case ErrorCodeUrl:
+		return &UrlValidator{AllowedSchemes: v.Values}, nil
+	case ErrorCodeEmail:
+		return &EmailValidator{}, nil
+	case ErrorCodeHostname:
+		return &HostnameValidator{}, nil
+	case ErrorCodeHostPort:
+		return &HostPortValidator{}, nil
+	case ErrorCodeCidr:
+		return &CidrValidator{}, nil
+	case ErrorCodeMacAddress:
+		return &MacAddressValidator{}, nil
+	case ErrorCodeUuid:
+		return &UuidValidator{}, nil
+	case ErrorCodeSemVer:
+		return &SemVerValidator{}, nil
+	case ErrorCodePort:
+		return &PortValidator{Min: v.Min, Max: v.Max}, nil
+	case ErrorCodeOneOf:
+		return &OneOfValidator{Values: v.Values}, nil
+	case ErrorCodeNotOneOf:
+		return &NotOneOfValidator{Values: v.Values}, nil
+	case ErrorCodePrefix:
+		return &PrefixValidator{Prefix: v.Value}, nil
+	case ErrorCodeSuffix:
+		return &SuffixValidator{Suffix: v.Value}, nil
+	case ErrorCodeContains:
+		return &ContainsValidator{Substring: v.Value}, nil
+	case ErrorCodeInteger:
+		return &IsIntegerValidator{}, nil
+	case ErrorCodeMin:
+		return &MinValidator{Min: v.Min}, nil
+	case ErrorCodeMax:
+		return &MaxValidator{Max: v.Max}, nil
+	case ErrorCodeChoice:
+		return &ChoiceValidator{}, nil
+	case ErrorCodeAllFieldsValid:
+		return &AllFieldsValid{}, nil
+	case ErrorCodeIsValid:
+		b.reference(path+".fields", v.Fields...)
+		return &IsValidValidator{FieldIds: v.Fields}, nil
+	case ErrorCodeEqualsField:
+		b.reference(path+".field", v.Field)
+		return &EqualsFieldValidator{FieldId: v.Field}, nil
+	case ErrorCodeNotEqualsField:
+		b.reference(path+".field", v.Field)
+		return &NotEqualsFieldValidator{FieldId: v.Field}, nil
+	case ErrorCodeGreaterThanField:
+		b.reference(path+".field", v.Field)
+		return &GreaterThanFieldValidator{FieldId: v.Field, Layout: v.Layout}, nil
+	case ErrorCodeLessThanField:
+		b.reference(path+".field", v.Field)
+		return &LessThanFieldValidator{FieldId: v.Field, Layout: v.Layout}, nil
+	case ErrorCodeRequiredIf:
+		b.reference(path+".field", v.Field)
+		return &RequiredIfValidator{FieldId: v.Field, Value: v.Value}, nil
+	case ErrorCodeRequiredUnless:
+		b.reference(path+".field", v.Field)
+		return &RequiredUnlessValidator{FieldId: v.Field, Value: v.Value}, nil
+	case ErrorCodeMutuallyExclusive:
+		b.reference(path+".fields", v.Fields...)
+		return &MutuallyExclusiveValidator{FieldIds: v.Fields}, nil
+	case ErrorCodeExpression:
+		expression, err := b.parseExpression(path+".expression", v.Expression)
+		if err != nil {
+			return nil, err
+		}
+		return &ExpressionValidator{Expression: expression, Message: v.Message}, nil
+	case ErrorCodeNot:
+		if len(v.Validators) != 1 {
+			return nil, &SchemaError{Path: path + ".validators", Message: "the not validator needs exactly one validator"}
+		}
+		validator, err := b.buildValidator(path+".validators[0]", v.Validators[0])
+		if err != nil {
+			return nil, err
+		}
+		return &NotValidator{Validator: validator, Message: v.Message}, nil
+	case ErrorCodeAnyOf, ErrorCodeAllOf:
+		validators, err := b.buildValidators(path+".validators", v.Validators)
+		if err != nil {
+			return nil, err
+		}
+		if v.Type == ErrorCodeAnyOf {
+			return &AnyOfValidator{Validators: validators}, nil
+		}
+		return &AllOfValidator{Validators: validators}, nil
+	case "when":
+		if v.Condition == nil || len(v.Validators) != 1 {
+			return nil, &SchemaError{Path: path, Message: "the when validator needs a condition and exactly one validator"}
+		}
+		condition, err := b.buildCondition(path+".condition", *v.Condition)
+		if err != nil {
+			return nil, err
+		}
+		validator, err := b.buildValidator(path+".validators[0]", v.Validators[0])
+		if err != nil {
+			return nil, err
+		}
+		return &WhenValidator{Condition: condition, Validator: validator}, nil
+	default:
+		return nil, &SchemaError{Path: path + ".type", Message: "unknown validator type (type: " + v.Type + ")"}
+	}
+}
+
+func parseLengthUnit(path string, unit string) (LengthUnit, error) {
+	switch unit {
+	case "", "runes":
+		return LengthInRunes, nil
+	case "graphemes":
+		return LengthInGraphemes, nil
+	case "bytes":
+		return LengthInBytes, nil
+	default:
+		return 0, &SchemaError{Path: path, Message: "unknown length unit (unit: " + unit + ", allowed units: runes, graphemes, bytes)"}
+	}
+}
+
+func parseValidationMode(path string, mode string) (ValidationMode, error) {
+	switch mode {
+	case "", "change":
+		return ValidateOnChange, nil
+	case "blur":
+		return ValidateOnBlur, nil
+	case "submit":
+		return ValidateOnSubmit, nil
+	default:
+		return 0, &SchemaError{Path: path, Message: "unknown validation mode (mode: " + mode + ", allowed modes: change, blur, submit)"}
+	}
+}
+
+func parseHiddenFieldPolicy(path string, policy string) (HiddenFieldPolicy, error) {
+	switch policy {
+	case "", "keep":
+		return KeepHiddenValues, nil
+	case "exclude":
+		return ExcludeHiddenValues, nil
+	case "clear":
+		return ClearHiddenValues, nil
+	case "reset":
+		return ResetHiddenValues, nil
+	default:
+		return 0, &SchemaError{Path: path, Message: "unknown hidden field policy (policy: " + policy + ", allowed policies: keep, exclude, clear, reset)"}
+	}
+}
+
+func parseGroupLayout(path string, layout string) (GroupLayout, error) {
+	switch layout {
+	case "", "list":
+		return GroupLayoutList, nil
+	case "card":
+		return GroupLayoutCard, nil
+	case "tab":
+		return GroupLayoutTab, nil
+	case "accordion":
+		return GroupLayoutAccordion, nil
+	case "grid":
+		return GroupLayoutGrid, nil
+	default:
+		return 0, &SchemaError{Path: path, Message: "unknown group layout (layout: " + layout + ", allowed layouts: list, card, tab, accordion, grid)"}
+	}
+}
+
+func (b *schemaBuilder) buildConditions(path string, conditionSchemas []ConditionSchema) ([]DisplayCondition, error) {
+	conditions := make([]DisplayCondition, 0, len(conditionSchemas))
+	for index, conditionSchema := range conditionSchemas {
+		condition, err := b.buildCondition(path+"["+strconv.Itoa(index)+"]", conditionSchema)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+func (b *schemaBuilder) buildCondition(path string, c ConditionSchema) (DisplayCondition, error) {
+	switch c.Type {
+	case "always":
+		return &AlwaysDisplay{}, nil
+	case "has_value":
+		b.reference(path+".field", c.Field)
+		return &HasValueDisplayCondition{FieldId: c.Field, Value: c.Value}, nil
+	case "is_valid":
+		b.reference(path+".fields", c.Fields...)
+		return &IsValidDisplayCondition{FieldIds: c.Fields}, nil
+	case "is_invalid":
+		b.reference(path+".fields", c.Fields...)
+		return &IsInvalidDisplayCondition{FieldIds: c.Fields}, nil
+	case "all_fields_valid":
+		return &AllFieldsValidDisplayCondition{}, nil
+	case "display_after":
+		b.reference(path+".field", c.Field)
+		return &DisplayAfter{FieldId: c.Field}, nil
+	case "and", "or":
+		conditions, err := b.buildConditions(path+".conditions", c.Conditions)
+		if err != nil {
+			return nil, err
+		}
+		if c.Type == "and" {
+			return &AndDisplayCondition{Conditions: conditions}, nil
+		}
+		return &OrDisplayCondition{Conditions: conditions}, nil
+	case "not":
+		if c.Condition == nil {
+			return nil, &SchemaError{Path: path, Message: "the not condition needs a condition"}
+		}
+		condition, err := b.buildCondition(path+".condition", *c.Condition)
+		if err != nil {
+			return nil, err
+		}
+		return &NotDisplayCondition{Condition: condition}, nil
+	case "expression":
+		expression, err := b.parseExpression(path+".expression", c.Expression)
+		if err != nil {
+			return nil, err
+		}
+		return &ExpressionDisplayCondition{Expression: expression}, nil
+	default:
+		return nil, &SchemaError{Path: path + ".type", Message: "unknown display condition type (type: " + c.Type + ")"}
+	}
+}
+
+func (b *schemaBuilder) parseExpression(path string, source string) (*Expression, error) {
+	expression, err := ParseExpression(source)
+	if err != nil {
+		return nil, &SchemaError{Path: path, Message: err.Error()}
+	}
+	b.expressions = append(b.expressions, schemaExpression{path: path, expression: expression})
+	return expression, nil
+}
+
+// expressionPath returns the path of the expression an error of CheckExpressions was found in
+func (b *schemaBuilder) expressionPath(err error) string {
+	var expressionErr *ExpressionError
+	if !errors.As(err, &expressionErr) {
+		return ""
+	}
+	for _, expression := range b.expressions {
+		if expression.expression == expressionErr.expression {
+			return expression.path
+		}
+	}
+	return ""
+}
+
+func (b *schemaBuilder) reference(path string, ids ...string) {
+	for _, id := range ids {
+		b.references = append(b.references, schemaReference{path: path, id: id})
+	}
+}
+
+var schemaNormalizers = map[string]func() Normalizer{
+	"trim":                func() Normalizer { return &TrimNormalizer{} },
+	"collapse_whitespace": func() Normalizer { return &CollapseWhitespaceNormalizer{} },
+	"nfc":                 func() Normalizer { return &NfcNormalizer{} },
+	"lower_case":          func() Normalizer { return &LowerCaseNormalizer{} },
+	"upper_case":          func() Normalizer { return &UpperCaseNormalizer{} },
+}
+
+func buildNormalizers(path string, names []string) ([]Normalizer, error) {
+	var normalizers []Normalizer
+	for index, name := range names {
+		newNormalizer, ok := schemaNormalizers[name]
+		if !ok {
+			allowed := make([]string, 0, len(schemaNormalizers))
+			for allowedName := range schemaNormalizers {
+				allowed = append(allowed, allowedName)
+			}
+			slices.Sort(allowed)
+			return nil, &SchemaError{Path: path + "[" + strconv.Itoa(index) + "]", Message: "unknown normalizer (normalizer: " + name + ", allowed normalizers: " + strings.Join(allowed, ", ") + ")"}
+		}
+		normalizers = append(normalizers, newNormalizer())
+	}
+	return normalizers, nil
+}
@@ -0,0 +1,180 @@
+package go_forms
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		path    string
+		message string
+	}{
+		{
+			name:    "unsupported version",
+			schema:  `{"version": 2, "fields": []}`,
+			message: "unsupported version",
+		},
+		{
+			name:    "fields and pages",
+			schema:  `{"fields": [{"type": "text", "id": "name"}], "pages": [{"id": "general", "fields": []}]}`,
+			message: "cannot have fields and pages",
+		},
+		{
+			name:    "unknown validation mode",
+			schema:  `{"validation_mode": "always", "fields": []}`,
+			path:    "validation_mode",
+			message: "unknown validation mode",
+		},
+		{
+			name:    "unknown hidden field policy",
+			schema:  `{"hidden_values": "drop", "fields": []}`,
+			path:    "hidden_values",
+			message: "unknown hidden field policy",
+		},
+		{
+			name:    "page without id",
+			schema:  `{"pages": [{"title": "General", "fields": []}]}`,
+			path:    "pages[0]",
+			message: "missing id",
+		},
+		{
+			name:    "field without id",
+			schema:  `{"fields": [{"type": "text"}]}`,
+			path:    "fields[0]",
+			message: "missing id",
+		},
+		{
+			name:    "duplicate id in a group",
+			schema:  `{"fields": [{"type": "text", "id": "name"}, {"type": "group", "id": "network", "fields": [{"type": "text", "id": "name"}]}]}`,
+			path:    "fields[1].fields[0]",
+			message: "duplicate id (id: name)",
+		},
+		{
+			name:    "unknown field type",
+			schema:  `{"fields": [{"type": "date", "id": "start"}]}`,
+			path:    "fields[0].type",
+			message: "unknown field type (type: date)",
+		},
+		{
+			name:    "number default",
+			schema:  `{"fields": [{"type": "number", "id": "port", "default": "high"}]}`,
+			path:    "fields[0].default",
+			message: "not an integer",
+		},
+		{
+			name:    "unknown group layout",
+			schema:  `{"fields": [{"type": "group", "id": "network", "layout": "table", "fields": []}]}`,
+			path:    "fields[0].layout",
+			message: "unknown group layout",
+		},
+		{
+			name:    "unknown validator",
+			schema:  `{"fields": [{"type": "text", "id": "name", "validators": [{"type": "not_empty"}, {"type": "palindrome"}]}]}`,
+			path:    "fields[0].validators[1].type",
+			message: "unknown validator type (type: palindrome)",
+		},
+		{
+			name:    "invalid regex",
+			schema:  `{"fields": [{"type": "text", "id": "name", "validators": [{"type": "regex", "pattern": "[a-z"}]}]}`,
+			path:    "fields[0].validators[0].pattern",
+			message: "missing closing ]",
+		},
+		{
+			name:    "unknown length unit",
+			schema:  `{"fields": [{"type": "text", "id": "name", "validators": [{"type": "max_length", "max": 3, "unit": "words"}]}]}`,
+			path:    "fields[0].validators[0].unit",
+			message: "unknown length unit",
+		},
+		{
+			name:    "reference to an unknown field",
+			schema:  `{"fields": [{"type": "text", "id": "confirm", "validators": [{"type": "equals_field", "field": "password"}]}]}`,
+			path:    "fields[0].validators[0].field",
+			message: "unknown field (id: password)",
+		},
+		{
+			name:    "not validator without validator",
+			schema:  `{"fields": [{"type": "text", "id": "name", "validators": [{"type": "not", "validators": []}]}]}`,
+			path:    "fields[0].validators[0].validators",
+			message: "exactly one validator",
+		},
+		{
+			name:    "when validator without condition",
+			schema:  `{"fields": [{"type": "text", "id": "name", "validators": [{"type": "when", "validators": [{"type": "not_empty"}]}]}]}`,
+			path:    "fields[0].validators[0]",
+			message: "needs a condition",
+		},
+		{
+			name:    "unknown display condition",
+			schema:  `{"fields": [{"type": "text", "id": "name", "display_conditions": [{"type": "and", "conditions": [{"type": "sometimes"}]}]}]}`,
+			path:    "fields[0].display_conditions[0].conditions[0].type",
+			message: "unknown display condition type (type: sometimes)",
+		},
+		{
+			name:    "expression syntax",
+			schema:  `{"fields": [{"type": "text", "id": "name", "display_conditions": [{"type": "expression", "expression": "len(name) >"}]}]}`,
+			path:    "fields[0].display_conditions[0].expression",
+			message: "unexpected end of expression",
+		},
+		{
+			name:    "expression types",
+			schema:  `{"fields": [{"type": "text", "id": "name", "validators": [{"type": "expression", "expression": "name > 3"}]}]}`,
+			path:    "fields[0].validators[0].expression",
+			message: "cannot be used with a string and a number",
+		},
+		{
+			name: "visible cycle",
+			schema: `{"hidden_values": "clear", "fields": [
+				{"type": "text", "id": "a", "display_conditions": [{"type": "expression", "expression": "visible(\"b\")"}]},
+				{"type": "text", "id": "b", "display_conditions": [{"type": "expression", "expression": "visible(\"a\")"}]}
+			]}`,
+			path:    "fields[0].display_conditions[0].expression",
+			message: "expressions depend on each other (visible(a) -> visible(b) -> visible(a))",
+		},
+		{
+			name:    "unknown normalizer",
+			schema:  `{"fields": [{"type": "text", "id": "name", "normalizers": ["trim", "title_case"]}]}`,
+			path:    "fields[0].normalizers[1]",
+			message: "unknown normalizer (normalizer: title_case, allowed normalizers: collapse_whitespace, lower_case, nfc, trim, upper_case)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := LoadSchema([]byte(test.schema))
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("LoadSchema returned %v, expected a *SchemaError", err)
+			}
+			if schemaErr.Path != test.path || !strings.Contains(schemaErr.Message, test.message) {
+				t.Errorf("error is %q at %q, expected %q at %q", schemaErr.Message, schemaErr.Path, test.message, test.path)
+			}
+		})
+	}
+}
+
+func TestLoadSchemaInvalidJson(t *testing.T) {
+	_, err := LoadSchema([]byte(`{"fields": [`))
+	var schemaErr *SchemaError
+	if err == nil || errors.As(err, &schemaErr) {
+		t.Errorf("LoadSchema returned %v, expected the error of the JSON decoder", err)
+	}
+}
+
+func TestSchemaDefaultEnv(t *testing.T) {
+	form, err := LoadSchema([]byte(`{"fields": [{"type": "number", "id": "port", "default": "25565", "default_env": "SCHEMA_TEST_PORT"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := form.lookupField("port")
+	if port.GetValue() != "25565" {
+		t.Errorf("port is %q without the environment variable, expected the default 25565", port.GetValue())
+	}
+	t.Setenv("SCHEMA_TEST_PORT", "25566")
+	form.Reset()
+	if port.GetValue() != "25566" {
+		t.Errorf("port is %q after the reset, expected the value of the environment variable", port.GetValue())
+	}
+}
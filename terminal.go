@@ -0,0 +1,352 @@
+package go_forms
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrCancelled is returned by the renderers if the user cancelled the form
+var ErrCancelled = &CustomError{Message: "Form was cancelled"}
+
+// terminalRenderer asks for the values of a form line by line
+type terminalRenderer struct {
+	form   *Form
+	reader *bufio.Reader
+	out    io.Writer
+	// asked contains the fields that were answered, shown the messages, headings and pages that were printed
+	asked map[string]bool
+	shown map[string]bool
+	// requests asks the reading goroutine for a line, which it sends to lines (see readLine)
+	requests chan struct{}
+	lines    chan terminalLine
+}
+
+type terminalLine struct {
+	text string
+	err  error
+}
+
+// newTerminalRenderer creates a renderer reading from in, a *bufio.Reader is used directly so that forms asked one after another
+// do not lose buffered input
+func newTerminalRenderer(form *Form, in io.Reader, out io.Writer) *terminalRenderer {
+	reader, ok := in.(*bufio.Reader)
+	if !ok {
+		reader = bufio.NewReader(in)
+	}
+	return &terminalRenderer{form: form, reader: reader, out: out, asked: make(map[string]bool), shown: make(map[string]bool)}
+}
+
+func (r *terminalRenderer) run(ctx context.Context) (map[string]string, error) {
+	defer r.close()
+	for {
+		r.printPage()
+		if err := r.askFields(ctx); err != nil {
+			return nil, err
+		}
+		if !r.form.IsLastPage() {
+			if err := r.form.NextPage(); err != nil {
+				r.printError(err)
+				if !r.forgetInvalidFields(r.form.GetFieldsToDisplay()) {
+					return nil, err
+				}
+			}
+			continue
+		}
+		if err := r.form.ValidateContext(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			r.printError(err)
+			if !r.forgetInvalidFields(r.form.GetFieldsToDisplay()) {
+				// The invalid field cannot be answered again (e.g. it is on another page)
+				return nil, err
+			}
+			continue
+		}
+		return r.form.GetFieldValues(), nil
+	}
+}
+
+// close stops the goroutine reading the input once its current read returns
+func (r *terminalRenderer) close() {
+	if r.requests != nil {
+		close(r.requests)
+	}
+}
+
+func (r *terminalRenderer) printPage() {
+	page := r.form.GetCurrentPage()
+	if page == nil || r.shown[page.Id] {
+		return
+	}
+	r.shown[page.Id] = true
+	fmt.Fprintf(r.out, "\n%s: %s\n", r.form.GetProgressText(), page.GetTitle())
+}
+
+func (r *terminalRenderer) askFields(ctx context.Context) error {
+	for {
+		field := r.nextField(r.form.GetFieldsToDisplay())
+		if field == nil {
+			return nil
+		}
+		if err := r.ask(ctx, field); err != nil {
+			return err
+		}
+		r.asked[field.GetId()] = true
+		r.forgetInvalidDependents(field)
+	}
+}
+
+// forgetInvalidDependents marks the answered fields that depend on the field and became invalid as not answered, so that they are asked again
+func (r *terminalRenderer) forgetInvalidDependents(field Field) {
+	if r.form.GetValidationMode() == ValidateOnSubmit {
+		return
+	}
+	for _, dependent := range r.form.GetDependentFields(field.GetId()) {
+		if !r.asked[dependent.GetId()] || dependent.IsValid() || errors.Is(dependent.GetError(), ErrValidationPending) {
+			continue
+		}
+		r.printError(&CustomError{Message: dependent.GetId() + " is not valid anymore (" + dependent.GetError().Error() + ")"})
+		delete(r.asked, dependent.GetId())
+	}
+}
+
+// nextField returns the first displayed field that was not answered yet and prints the messages and headings before it
+func (r *terminalRenderer) nextField(fields []Field) Field {
+	for _, field := range fields {
+		switch field := field.(type) {
+		case *FieldBaseType:
+			// Do nothing
+		case *Message:
+			r.printOnce(field.Id, field.GetValue())
+		case *FieldGroup:
+			switch {
+			case field.Layout == GroupLayoutTab || field.Layout == GroupLayoutAccordion:
+				// Tabs and accordion sections become sections asked one after another
+				r.printOnce(field.Id, underline(field.GetTitle()))
+			case field.Layout == GroupLayoutCard && field.GetHeading() != "":
+				r.printOnce(field.Id, underline(field.GetHeading()))
+			case field.GetHeading() != "":
+				r.printOnce(field.Id, "\n"+field.GetHeading())
+			}
+			if next := r.nextField(field.GetFieldsToDisplay()); next != nil {
+				return next
+			}
+		default:
+			if base := getFieldBase(field); base != nil && !base.IsEditable() {
+				r.printLocked(field)
+			} else if !r.asked[field.GetId()] {
+				return field
+			}
+		}
+	}
+	return nil
+}
+
+// printLocked prints the value of a read-only or disabled field instead of asking for it
+func (r *terminalRenderer) printLocked(field Field) {
+	text := field.GetId() + ": "
+	if textField, ok := field.(interface{ GetPrompt() string }); ok {
+		text = textField.GetPrompt() + ": "
+	}
+	value := field.GetValue()
+	if choiceField, ok := field.(*MultipleChoiceField); ok {
+		if option, ok := choiceField.Options[value]; ok {
+			value = option.Label
+		}
+	}
+	if getFieldBase(field).IsDisabled() {
+		value = strings.TrimSpace(value + " (disabled)")
+	}
+	r.printOnce(field.GetId(), text+value)
+}
+
+// underline returns the heading of a section with an empty line before it and a line of dashes below it
+func underline(heading string) string {
+	return "\n" + heading + "\n" + strings.Repeat("-", utf8.RuneCountInString(heading))
+}
+
+func (r *terminalRenderer) printOnce(id string, text string) {
+	if !r.shown[id] {
+		r.shown[id] = true
+		fmt.Fprintln(r.out, text)
+	}
+}
+
+// forgetInvalidFields marks the invalid fields as not answered so that they are asked again and returns false if there are none
+func (r *terminalRenderer) forgetInvalidFields(fields []Field) bool {
+	forgotten := false
+	for _, field := range fields {
+		if field.IsValid() {
+			continue
+		}
+		if group, ok := field.(*FieldGroup); ok {
+			// The group validators may fail although the fields of the group are valid, all of them are asked again then
+			if !r.forgetInvalidFields(group.GetFieldsToDisplay()) {
+				for _, child := range group.GetFieldsToDisplay() {
+					forgotten = forgotten || r.asked[child.GetId()]
+					delete(r.asked, child.GetId())
+				}
+			} else {
+				forgotten = true
+			}
+			continue
+		}
+		forgotten = forgotten || r.asked[field.GetId()]
+		delete(r.asked, field.GetId())
+	}
+	return forgotten
+}
+
+// ask reads the value of a field until it is valid (unless the form is validated on submit), an empty line keeps the current value
+func (r *terminalRenderer) ask(ctx context.Context, field Field) error {
+	for {
+		r.printPrompt(field)
+		line, err := r.readLine(ctx)
+		if err != nil {
+			return err
+		}
+		if line != "" {
+			value, ok := parseTerminalInput(field, line)
+			if !ok {
+				r.printError(&CustomError{Message: "Unknown option (" + line + ")"})
+				continue
+			}
+			field.SetValue(value)
+		}
+		r.form.MarkTouched(field.GetId())
+		if r.form.GetValidationMode() == ValidateOnSubmit {
+			// The value is validated with the whole form, invalid fields are asked again then
+			return nil
+		}
+		if base := getFieldBase(field); base != nil && len(base.AsyncValidators) > 0 {
+			if err := base.waitAsyncValidation(ctx); err != nil {
+				return err
+			}
+		}
+		if field.IsValid() {
+			return nil
+		}
+		r.printError(field.GetError())
+	}
+}
+
+func (r *terminalRenderer) printPrompt(field Field) {
+	prompt := field.GetId()
+	placeholder := ""
+	if textField, ok := field.(interface {
+		GetPrompt() string
+		GetPlaceholder() string
+	}); ok {
+		prompt = textField.GetPrompt()
+		placeholder = textField.GetPlaceholder()
+	}
+	if base := getFieldBase(field); base != nil && base.IsRequired() {
+		prompt += " *"
+	}
+	if base := getFieldBase(field); base != nil && base.GetHelpText() != "" {
+		fmt.Fprintln(r.out, base.GetHelpText())
+	}
+	current := field.GetValue()
+	if choiceField, ok := field.(*MultipleChoiceField); ok {
+		fmt.Fprintln(r.out, prompt+":")
+		for index, key := range sortedOptionKeys(choiceField) {
+			option := choiceField.Options[key]
+			if option.Description != "" {
+				fmt.Fprintf(r.out, "  %d) %s - %s\n", index+1, option.Label, option.Description)
+			} else {
+				fmt.Fprintf(r.out, "  %d) %s\n", index+1, option.Label)
+			}
+		}
+		prompt = "Choose"
+		if option, ok := choiceField.Options[current]; ok {
+			current = option.Label
+		}
+	}
+	if placeholder != "" {
+		prompt += " (" + placeholder + ")"
+	}
+	if current != "" {
+		prompt += " [" + current + "]"
+	}
+	fmt.Fprint(r.out, prompt+": ")
+}
+
+func (r *terminalRenderer) printError(err error) {
+	fmt.Fprintln(r.out, "Error: "+err.Error())
+}
+
+// readLine reads the next line without the line break, the end of the input cancels the form.
+// The lines are read by a goroutine so that the context can end the form while it waits for a line. The goroutine only reads
+// when a line is requested, so no input is consumed after the form ended.
+func (r *terminalRenderer) readLine(ctx context.Context) (string, error) {
+	if r.requests == nil {
+		r.requests = make(chan struct{})
+		r.lines = make(chan terminalLine, 1)
+		go func() {
+			for range r.requests {
+				text, err := r.reader.ReadString('\n')
+				r.lines <- terminalLine{text: text, err: err}
+			}
+		}()
+	}
+	r.requests <- struct{}{}
+	var line terminalLine
+	select {
+	case <-ctx.Done():
+		fmt.Fprintln(r.out)
+		return "", ctx.Err()
+	case line = <-r.lines:
+	}
+	if line.err == io.EOF && line.text == "" {
+		fmt.Fprintln(r.out)
+		return "", ErrCancelled
+	}
+	if line.err != nil && line.err != io.EOF {
+		return "", line.err
+	}
+	return strings.TrimRight(line.text, "\r\n"), nil
+}
+
+// parseTerminalInput converts the input to the value of the field, options can be chosen by number, key or label
+func parseTerminalInput(field Field, input string) (string, bool) {
+	choiceField, ok := field.(*MultipleChoiceField)
+	if !ok {
+		return input, true
+	}
+	if _, ok := choiceField.Options[input]; ok {
+		return input, true
+	}
+	keys := sortedOptionKeys(choiceField)
+	if number, err := strconv.Atoi(input); err == nil && number >= 1 && number <= len(keys) {
+		return keys[number-1], true
+	}
+	for _, key := range keys {
+		if strings.EqualFold(choiceField.Options[key].Label, input) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func sortedOptionKeys(field *MultipleChoiceField) []string {
+	keys := make([]string, 0, len(field.Options))
+	for key := range field.Options {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// FormToTerminal asks for the values of the form line by line and returns the values once the form is valid.
+// Prompts and errors are written to out, an empty line keeps the current value and the end of the input returns ErrCancelled.
+func FormToTerminal(form *Form, in io.Reader, out io.Writer) (map[string]string, error) {
+	return newTerminalRenderer(form, in, out).run(context.Background())
+}
@@ -0,0 +1,39 @@
+package go_forms
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTerminalGroupLayouts(t *testing.T) {
+	form := NewForm(
+		NewFieldGroup("general", nil, nil, "General", NewTextField("name", nil, nil, "", "Name", "lobby")),
+		newLayoutGroup("server", GroupLayoutCard, 0, "Server", NewTextField("motd", nil, nil, "", "Message of the day", "Welcome")),
+		newLayoutGroup("world", GroupLayoutTab, 0, "World", NewTextField("seed", nil, nil, "", "Seed", "42")),
+		newLayoutGroup("players", GroupLayoutTab, 0, "", NewNumberField("slots", nil, nil, "", "Slots", 20)),
+		newLayoutGroup("network", GroupLayoutAccordion, 0, "Network", NewNumberField("port", nil, nil, "", "Port", 25565)),
+		newLayoutGroup("resources", GroupLayoutGrid, 3, "Resources", NewNumberField("ram", nil, nil, "", "RAM", 2048)),
+	)
+	var out bytes.Buffer
+	if _, err := FormToTerminal(form, strings.NewReader(strings.Repeat("\n", 7)), &out); err != nil {
+		t.Fatalf("FormToTerminal returned %v (output: %s)", err, out.String())
+	}
+	// Cards, tabs and accordion sections are underlined sections, tabs without heading are titled with their id
+	expected := []string{
+		"\nGeneral\nName [lobby]: ",
+		"\nServer\n------\nMessage of the day [Welcome]: ",
+		"\nWorld\n-----\nSeed [42]: ",
+		"\nplayers\n-------\nSlots [20]: ",
+		"\nNetwork\n-------\nPort [25565]: ",
+		"\nResources\nRAM [2048]: ",
+	}
+	output := out.String()
+	for _, section := range expected {
+		index := strings.Index(output, section)
+		if index == -1 {
+			t.Fatalf("output does not contain %q in order: %s", section, out.String())
+		}
+		output = output[index+len(section):]
+	}
+}
@@ -0,0 +1,267 @@
+package go_forms
+
+import (
+	"net"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Defining additional Validators for the Text Field Type
+// All of them accept empty values, combine them with NotEmptyValidator to require a value.
+
+var (
+	hostnameRegex = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*\.?$`)
+	uuidRegex     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	semVerRegex   = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+)
+
+// validateValue sets the error with the given code and message if the value is not empty and valid returns false
+func validateValue(field any, valid func(value string) bool, code string, message string) bool {
+	base := getFieldBase(field)
+	if base.GetValue() == "" || valid(base.GetValue()) {
+		return true
+	}
+	base.setError(&CustomError{Code: code, Message: message})
+	return false
+}
+
+type EmailValidator struct{}
+
+func (v *EmailValidator) Validate(field any) bool {
+	return validateValue(field, func(value string) bool {
+		address, err := mail.ParseAddress(value)
+		return err == nil && address.Address == value
+	}, ErrorCodeEmail, "Field is not a valid email address")
+}
+
+type HostnameValidator struct{}
+
+func (v *HostnameValidator) Validate(field any) bool {
+	return validateValue(field, isHostname, ErrorCodeHostname, "Field is not a valid hostname")
+}
+
+func isHostname(value string) bool {
+	return len(value) <= 253 && hostnameRegex.MatchString(value)
+}
+
+// HostPortValidator validates values like "example.com:25565" or "[::1]:8080"
+type HostPortValidator struct{}
+
+func (v *HostPortValidator) Validate(field any) bool {
+	return validateValue(field, func(value string) bool {
+		host, port, err := net.SplitHostPort(value)
+		if err != nil || !isPort(port, 1, 65535) {
+			return false
+		}
+		return net.ParseIP(host) != nil || isHostname(host)
+	}, ErrorCodeHostPort, "Field is not a valid host:port combination")
+}
+
+type CidrValidator struct{}
+
+func (v *CidrValidator) Validate(field any) bool {
+	return validateValue(field, func(value string) bool {
+		_, _, err := net.ParseCIDR(value)
+		return err == nil
+	}, ErrorCodeCidr, "Field is not a valid CIDR notation")
+}
+
+type Ipv4Validator struct{}
+
+func (v *Ipv4Validator) Validate(field any) bool {
+	return validateValue(field, func(value string) bool {
+		ip := net.ParseIP(value)
+		return ip != nil && ip.To4() != nil && !strings.Contains(value, ":")
+	}, ErrorCodeIpv4, "Field is not a valid IPv4 address")
+}
+
+type Ipv6Validator struct{}
+
+func (v *Ipv6Validator) Validate(field any) bool {
+	return validateValue(field, func(value string) bool {
+		return net.ParseIP(value) != nil && strings.Contains(value, ":")
+	}, ErrorCodeIpv6, "Field is not a valid IPv6 address")
+}
+
+type MacAddressValidator struct{}
+
+func (v *MacAddressValidator) Validate(field any) bool {
+	return validateValue(field, func(value string) bool {
+		_, err := net.ParseMAC(value)
+		return err == nil
+	}, ErrorCodeMacAddress, "Field is not a valid MAC address")
+}
+
+type UuidValidator struct{}
+
+func (v *UuidValidator) Validate(field any) bool {
+	return validateValue(field, uuidRegex.MatchString, ErrorCodeUuid, "Field is not a valid UUID")
+}
+
+// SemVerValidator validates semantic versions like "1.2.3-beta.1+build" (without a leading "v")
+type SemVerValidator struct{}
+
+func (v *SemVerValidator) Validate(field any) bool {
+	return validateValue(field, semVerRegex.MatchString, ErrorCodeSemVer, "Field is not a valid semantic version")
+}
+
+// PortValidator validates port numbers between Min and Max. Min defaults to 1 and Max to 65535.
+type PortValidator struct {
+	Min int
+	Max int
+}
+
+func (v *PortValidator) Validate(field any) bool {
+	minPort, maxPort := v.Min, v.Max
+	if minPort == 0 {
+		minPort = 1
+	}
+	if maxPort == 0 {
+		maxPort = 65535
+	}
+	return validateValue(field, func(value string) bool {
+		return isPort(value, minPort, maxPort)
+	}, ErrorCodePort, "Field is not a valid port (min port: "+strconv.Itoa(minPort)+", max port: "+strconv.Itoa(maxPort)+")")
+}
+
+func isPort(value string, minPort int, maxPort int) bool {
+	port, err := strconv.Atoi(value)
+	return err == nil && minPort <= port && port <= maxPort
+}
+
+type OneOfValidator struct {
+	Values []string
+}
+
+func (v *OneOfValidator) Validate(field any) bool {
+	return validateValue(field, func(value string) bool {
+		return slices.Contains(v.Values, value)
+	}, ErrorCodeOneOf, "Field value is not allowed (allowed values: "+strings.Join(v.Values, ", ")+")")
+}
+
+type NotOneOfValidator struct {
+	Values []string
+}
+
+func (v *NotOneOfValidator) Validate(field any) bool {
+	return validateValue(field, func(value string) bool {
+		return !slices.Contains(v.Values, value)
+	}, ErrorCodeNotOneOf, "Field value is not allowed (forbidden values: "+strings.Join(v.Values, ", ")+")")
+}
+
+type PrefixValidator struct {
+	Prefix string
+}
+
+func (v *PrefixValidator) Validate(field any) bool {
+	return validateValue(field, func(value string) bool {
+		return strings.HasPrefix(value, v.Prefix)
+	}, ErrorCodePrefix, "Field has to start with "+v.Prefix)
+}
+
+type SuffixValidator struct {
+	Suffix string
+}
+
+func (v *SuffixValidator) Validate(field any) bool {
+	return validateValue(field, func(value string) bool {
+		return strings.HasSuffix(value, v.Suffix)
+	}, ErrorCodeSuffix, "Field has to end with "+v.Suffix)
+}
+
+type ContainsValidator struct {
+	Substring string
+}
+
+func (v *ContainsValidator) Validate(field any) bool {
+	return validateValue(field, func(value string) bool {
+		return strings.Contains(value, v.Substring)
+	}, ErrorCodeContains, "Field has to contain "+v.Substring)
+}
+
+// CharacterClass is a set of character classes that can be combined with |
+type CharacterClass int
+
+const (
+	CharacterClassLower CharacterClass = 1 << iota
+	CharacterClassUpper
+	CharacterClassDigit
+	CharacterClassSpace
+	CharacterClassPunctuation
+	CharacterClassLetter       = CharacterClassLower | CharacterClassUpper
+	CharacterClassAlphanumeric = CharacterClassLetter | CharacterClassDigit
+)
+
+var characterClassNames = []struct {
+	class CharacterClass
+	name  string
+}{
+	{CharacterClassLower, "lowercase letters"},
+	{CharacterClassUpper, "uppercase letters"},
+	{CharacterClassDigit, "digits"},
+	{CharacterClassSpace, "spaces"},
+	{CharacterClassPunctuation, "punctuation"},
+}
+
+func (c CharacterClass) contains(r rune) bool {
+	return c&CharacterClassLower != 0 && unicode.IsLower(r) ||
+		c&CharacterClassUpper != 0 && unicode.IsUpper(r) ||
+		c&CharacterClassDigit != 0 && unicode.IsDigit(r) ||
+		c&CharacterClassSpace != 0 && unicode.IsSpace(r) ||
+		c&CharacterClassPunctuation != 0 && (unicode.IsPunct(r) || unicode.IsSymbol(r))
+}
+
+func (c CharacterClass) String() string {
+	var names []string
+	for _, class := range characterClassNames {
+		if c&class.class != 0 {
+			names = append(names, class.name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// CharacterClassValidator validates that the value only contains characters of the Allowed classes or the Extra characters
+type CharacterClassValidator struct {
+	Allowed CharacterClass
+	Extra   string
+}
+
+func (v *CharacterClassValidator) Validate(field any) bool {
+	message := "Field may only contain " + v.Allowed.String()
+	if v.Extra != "" {
+		message += " and the characters " + v.Extra
+	}
+	return validateValue(field, func(value string) bool {
+		for _, r := range value {
+			if !v.Allowed.contains(r) && !strings.ContainsRune(v.Extra, r) {
+				return false
+			}
+		}
+		return true
+	}, ErrorCodeCharacterClass, message)
+}
+
+// ContainsCharacterClassValidator validates that the value contains at least one character of each of the Required classes
+type ContainsCharacterClassValidator struct {
+	Required CharacterClass
+}
+
+func (v *ContainsCharacterClassValidator) Validate(field any) bool {
+	for _, class := range characterClassNames {
+		if v.Required&class.class == 0 {
+			continue
+		}
+		valid := validateValue(field, func(value string) bool {
+			return strings.IndexFunc(value, class.class.contains) != -1
+		}, ErrorCodeMissingCharacter, "Field has to contain at least one of the following: "+class.name)
+		if !valid {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,265 @@
+package go_forms
+
+import (
+	"errors"
+	"testing"
+)
+
+// validate runs the validator on a text field with the value and returns the code of the error or "" if the value is valid
+func validate(t *testing.T, validator Validator, value string) string {
+	t.Helper()
+	field := NewTextField("value", nil, nil, "", "Value", value)
+	valid := validator.Validate(field)
+	if valid {
+		return ""
+	}
+	var customErr *CustomError
+	if !errors.As(field.GetError(), &customErr) {
+		t.Fatalf("%T rejected %q with %v, expected a *CustomError", validator, value, field.GetError())
+	}
+	return customErr.Code
+}
+
+func TestTextValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator Validator
+		valid     []string
+		invalid   []string
+		code      string
+	}{
+		{
+			name:      "email",
+			validator: &EmailValidator{},
+			valid:     []string{"steve@example.com", "first.last+tag@mc.example.org"},
+			invalid:   []string{"steve", "steve@", "Steve <steve@example.com>", "@example.com"},
+			code:      ErrorCodeEmail,
+		},
+		{
+			name:      "hostname",
+			validator: &HostnameValidator{},
+			valid:     []string{"localhost", "mc.example.com", "example.com.", "a-b.c"},
+			invalid:   []string{"-start.com", "end-.com", "under_score.com", "a..b"},
+			code:      ErrorCodeHostname,
+		},
+		{
+			name:      "host and port",
+			validator: &HostPortValidator{},
+			valid:     []string{"example.com:25565", "127.0.0.1:80", "[::1]:8080"},
+			invalid:   []string{"example.com", "example.com:0", "example.com:65536", "::1:8080", "bad_host:80"},
+			code:      ErrorCodeHostPort,
+		},
+		{
+			name:      "cidr",
+			validator: &CidrValidator{},
+			valid:     []string{"10.0.0.0/8", "192.168.1.0/24", "fd00::/8"},
+			invalid:   []string{"10.0.0.0", "10.0.0.0/33", "10.0.0/8"},
+			code:      ErrorCodeCidr,
+		},
+		{
+			name:      "ip",
+			validator: &IpValidator{},
+			valid:     []string{"127.0.0.1", "::1"},
+			invalid:   []string{"256.0.0.1", "localhost"},
+			code:      ErrorCodeIp,
+		},
+		{
+			name:      "ipv4",
+			validator: &Ipv4Validator{},
+			valid:     []string{"127.0.0.1", "0.0.0.0", "255.255.255.255"},
+			invalid:   []string{"::1", "::ffff:127.0.0.1", "256.0.0.1", "1.2.3"},
+			code:      ErrorCodeIpv4,
+		},
+		{
+			name:      "ipv6",
+			validator: &Ipv6Validator{},
+			valid:     []string{"::1", "fe80::1", "::ffff:127.0.0.1", "2001:db8:0:0:0:0:0:1"},
+			invalid:   []string{"127.0.0.1", "fe80::g", "1:2:3:4:5:6:7:8:9"},
+			code:      ErrorCodeIpv6,
+		},
+		{
+			name:      "mac address",
+			validator: &MacAddressValidator{},
+			valid:     []string{"00:1a:2b:3c:4d:5e", "00-1A-2B-3C-4D-5E", "001a.2b3c.4d5e"},
+			invalid:   []string{"00:1a:2b:3c:4d", "00:1a:2b:3c:4d:zz"},
+			code:      ErrorCodeMacAddress,
+		},
+		{
+			name:      "uuid",
+			validator: &UuidValidator{},
+			valid:     []string{"123e4567-e89b-12d3-a456-426614174000", "123E4567-E89B-12D3-A456-426614174000"},
+			invalid:   []string{"123e4567e89b12d3a456426614174000", "123e4567-e89b-12d3-a456-42661417400", "g23e4567-e89b-12d3-a456-426614174000"},
+			code:      ErrorCodeUuid,
+		},
+		{
+			name:      "semantic version",
+			validator: &SemVerValidator{},
+			valid:     []string{"1.20.4", "0.0.1", "1.2.3-beta.1", "1.2.3-rc.1+build.5"},
+			invalid:   []string{"v1.2.3", "1.2", "01.2.3", "1.2.3-", "1.2.3-01"},
+			code:      ErrorCodeSemVer,
+		},
+		{
+			name:      "port",
+			validator: &PortValidator{},
+			valid:     []string{"1", "25565", "65535"},
+			invalid:   []string{"0", "65536", "-1", "http"},
+			code:      ErrorCodePort,
+		},
+		{
+			name:      "port range",
+			validator: &PortValidator{Min: 1024, Max: 49151},
+			valid:     []string{"1024", "25565", "49151"},
+			invalid:   []string{"80", "1023", "49152"},
+			code:      ErrorCodePort,
+		},
+		{
+			name:      "url",
+			validator: &UrlValidator{},
+			valid:     []string{"https://example.com", "http://example.com:8080/path?query=1", "HTTPS://example.com"},
+			invalid:   []string{"example.com", "https://", "/path", "://example.com"},
+			code:      ErrorCodeUrl,
+		},
+		{
+			name:      "url with a scheme that is not allowed",
+			validator: &UrlValidator{},
+			invalid:   []string{"ftp://example.com", "file://host/etc/passwd"},
+			code:      ErrorCodeUrlScheme,
+		},
+		{
+			name:      "url with allowed schemes",
+			validator: &UrlValidator{AllowedSchemes: []string{"ftp"}},
+			valid:     []string{"ftp://example.com"},
+			invalid:   []string{"https://example.com"},
+			code:      ErrorCodeUrlScheme,
+		},
+		{
+			name:      "regex",
+			validator: &RegexValidator{RegexPattern: "^[a-z]+$"},
+			valid:     []string{"lobby"},
+			invalid:   []string{"Lobby", "lobby1"},
+			code:      ErrorCodeRegex,
+		},
+		{
+			name:      "one of",
+			validator: &OneOfValidator{Values: []string{"paper", "vanilla"}},
+			valid:     []string{"paper", "vanilla"},
+			invalid:   []string{"Paper", "spigot"},
+			code:      ErrorCodeOneOf,
+		},
+		{
+			name:      "not one of",
+			validator: &NotOneOfValidator{Values: []string{"admin", "root"}},
+			valid:     []string{"steve", "Admin"},
+			invalid:   []string{"admin", "root"},
+			code:      ErrorCodeNotOneOf,
+		},
+		{
+			name:      "prefix",
+			validator: &PrefixValidator{Prefix: "mc-"},
+			valid:     []string{"mc-lobby", "mc-"},
+			invalid:   []string{"lobby", "MC-lobby"},
+			code:      ErrorCodePrefix,
+		},
+		{
+			name:      "suffix",
+			validator: &SuffixValidator{Suffix: ".jar"},
+			valid:     []string{"paper.jar"},
+			invalid:   []string{"paper.zip", "jar"},
+			code:      ErrorCodeSuffix,
+		},
+		{
+			name:      "contains",
+			validator: &ContainsValidator{Substring: "craft"},
+			valid:     []string{"minecraft", "craft"},
+			invalid:   []string{"mine"},
+			code:      ErrorCodeContains,
+		},
+		{
+			name:      "character classes",
+			validator: &CharacterClassValidator{Allowed: CharacterClassLower | CharacterClassDigit, Extra: "-_"},
+			valid:     []string{"lobby-1", "survival_2", "größe"},
+			invalid:   []string{"Lobby", "lobby 1", "lobby.1"},
+			code:      ErrorCodeCharacterClass,
+		},
+		{
+			name:      "alphanumeric",
+			validator: &CharacterClassValidator{Allowed: CharacterClassAlphanumeric},
+			valid:     []string{"Lobby1"},
+			invalid:   []string{"Lobby!", "Lobby 1"},
+			code:      ErrorCodeCharacterClass,
+		},
+		{
+			name:      "contains character classes",
+			validator: &ContainsCharacterClassValidator{Required: CharacterClassUpper | CharacterClassDigit | CharacterClassPunctuation},
+			valid:     []string{"Secret1!", "A1+"},
+			invalid:   []string{"secret1!", "Secret!", "Secret1"},
+			code:      ErrorCodeMissingCharacter,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Like all text validators they accept empty values
+			for _, value := range append([]string{""}, test.valid...) {
+				if code := validate(t, test.validator, value); code != "" {
+					t.Errorf("%q was rejected with the code %q", value, code)
+				}
+			}
+			for _, value := range test.invalid {
+				if code := validate(t, test.validator, value); code != test.code {
+					t.Errorf("%q was rejected with the code %q, expected %q", value, code, test.code)
+				}
+			}
+		})
+	}
+}
+
+func TestValidatorMessages(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator Validator
+		value     string
+		message   string
+	}{
+		{
+			name:      "port",
+			validator: &PortValidator{Min: 1024},
+			value:     "80",
+			message:   "Field is not a valid port (min port: 1024, max port: 65535)",
+		},
+		{
+			name:      "url scheme",
+			validator: &UrlValidator{},
+			value:     "ftp://example.com",
+			message:   "URL scheme is not allowed (scheme: ftp, allowed schemes: http, https)",
+		},
+		{
+			name:      "one of",
+			validator: &OneOfValidator{Values: []string{"paper", "vanilla"}},
+			value:     "spigot",
+			message:   "Field value is not allowed (allowed values: paper, vanilla)",
+		},
+		{
+			name:      "character classes",
+			validator: &CharacterClassValidator{Allowed: CharacterClassLower | CharacterClassDigit, Extra: "-_"},
+			value:     "Lobby",
+			message:   "Field may only contain lowercase letters, digits and the characters -_",
+		},
+		{
+			name:      "missing character",
+			validator: &ContainsCharacterClassValidator{Required: CharacterClassUpper | CharacterClassDigit},
+			value:     "Secret",
+			message:   "Field has to contain at least one of the following: digits",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			field := NewTextField("value", nil, nil, "", "Value", test.value)
+			if test.validator.Validate(field) {
+				t.Fatalf("%q is valid", test.value)
+			}
+			if message := field.GetError().Error(); message != test.message {
+				t.Errorf("message is %q, expected %q", message, test.message)
+			}
+		})
+	}
+}
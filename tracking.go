@@ -0,0 +1,113 @@
+package go_forms
+
+// Defining the touched and dirty tracking of the Form Type
+
+// ValidationMode decides when the renderers show the error of an invalid field (see ShouldShowError).
+// The errors of all fields are shown after the user tried to submit the form or to go to the next wizard page.
+type ValidationMode int
+
+const (
+	// ValidateOnChange shows the error once the user changed the value of the field or left it
+	ValidateOnChange ValidationMode = iota
+	// ValidateOnBlur shows the error once the user left the field
+	ValidateOnBlur
+	// ValidateOnSubmit shows the errors only after the user tried to submit the form
+	ValidateOnSubmit
+)
+
+func (f *Form) GetValidationMode() ValidationMode {
+	f.stateMu.RLock()
+	defer f.stateMu.RUnlock()
+	return f.validationMode
+}
+
+// SetValidationMode changes when the renderers show the errors, it should be set before the form is rendered
+func (f *Form) SetValidationMode(mode ValidationMode) {
+	f.stateMu.Lock()
+	defer f.stateMu.Unlock()
+	f.validationMode = mode
+}
+
+// MarkTouched marks the field with the given id as touched, the renderers call it when the user leaves a field.
+// The renderers are only notified if the field is invalid and its error should be shown now (see ShouldShowError).
+func (f *Form) MarkTouched(id string) {
+	shown := f.ShouldShowError(id)
+	f.stateMu.Lock()
+	if f.touched[id] {
+		f.stateMu.Unlock()
+		return
+	}
+	if f.touched == nil {
+		f.touched = make(map[string]bool)
+	}
+	f.touched[id] = true
+	f.stateMu.Unlock()
+	if shown || !f.ShouldShowError(id) {
+		return
+	}
+	if field := f.lookupField(id); field == nil || field.IsValid() {
+		return
+	}
+	f.notifyValidation(id)
+}
+
+// IsTouched returns true if the user left the field with the given id since the form was created or marked as pristine
+func (f *Form) IsTouched(id string) bool {
+	f.stateMu.RLock()
+	defer f.stateMu.RUnlock()
+	return f.touched[id]
+}
+
+// IsDirty returns true if the value of any field differs from its value when the form was created or marked as pristine
+func (f *Form) IsDirty() bool {
+	return len(f.DirtyFields()) > 0
+}
+
+// DirtyFields returns the ids of the fields whose value differs from their value when the form was created or marked as pristine
+func (f *Form) DirtyFields() []string {
+	f.stateMu.RLock()
+	pristine := f.pristine
+	f.stateMu.RUnlock()
+	var ids []string
+	for _, field := range f.GetAllFields() {
+		switch field.(type) {
+		case *Message, *FieldGroup:
+			continue
+		}
+		if field.GetValue() != pristine[field.GetId()] {
+			ids = append(ids, field.GetId())
+		}
+	}
+	return ids
+}
+
+func (f *Form) isFieldDirty(id string) bool {
+	f.stateMu.RLock()
+	pristine := f.pristine
+	f.stateMu.RUnlock()
+	field := f.lookupField(id)
+	return field != nil && field.GetValue() != pristine[id]
+}
+
+// MarkPristine makes the current values the ones IsDirty compares with and marks all fields as untouched, e.g. after the values were saved
+func (f *Form) MarkPristine() {
+	pristine := f.getAllFieldValues()
+	f.stateMu.Lock()
+	f.pristine = pristine
+	f.touched = nil
+	f.stateMu.Unlock()
+	f.notifyValidation("")
+}
+
+// ShouldShowError returns true if the renderers should show the error of the field with the given id according to the validation mode.
+// It does not check if the field is valid and does not know about submit attempts, which the renderers track themselves.
+func (f *Form) ShouldShowError(id string) bool {
+	switch f.GetValidationMode() {
+	case ValidateOnBlur:
+		return f.IsTouched(id)
+	case ValidateOnSubmit:
+		return false
+	default:
+		return f.IsTouched(id) || f.isFieldDirty(id)
+	}
+}
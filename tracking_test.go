@@ -0,0 +1,42 @@
+package go_forms
+
+import "testing"
+
+func TestMarkTouchedNotifiesOnlyNewErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   ValidationMode
+		value  string
+		change bool
+		notify bool
+	}{
+		{name: "invalid on blur", mode: ValidateOnBlur, value: "ab", notify: true},
+		{name: "valid on blur", mode: ValidateOnBlur, value: "lobby"},
+		{name: "invalid on submit", mode: ValidateOnSubmit, value: "ab"},
+		{name: "invalid and pristine on change", mode: ValidateOnChange, value: "ab", notify: true},
+		{name: "invalid and changed on change", mode: ValidateOnChange, value: "ab", change: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			name := NewTextField("name", nil, []Validator{&MinLengthValidator{MinLength: 3}}, "", "Name", "")
+			form := NewForm(name)
+			form.SetValidationMode(test.mode)
+			name.SetValue(test.value)
+			if !test.change {
+				form.MarkPristine()
+			}
+			notified := 0
+			form.addValidationListener(func(_ string) {
+				notified++
+			})
+			form.MarkTouched("name")
+			form.MarkTouched("name")
+			if expected := map[bool]int{true: 1}[test.notify]; notified != expected {
+				t.Errorf("listener was notified %d times, expected %d", notified, expected)
+			}
+			if !form.IsTouched("name") {
+				t.Errorf("field is not touched")
+			}
+		})
+	}
+}
@@ -1,6 +1,54 @@
 package go_forms
 
+// Error codes of the built-in validators. They are stable and can be used to translate or customize error messages.
+const (
+	ErrorCodeAllFieldsValid    = "all_fields_valid"
+	ErrorCodeIsValid           = "is_valid"
+	ErrorCodeEqualsField       = "equals_field"
+	ErrorCodeNotEqualsField    = "not_equals_field"
+	ErrorCodeGreaterThanField  = "greater_than_field"
+	ErrorCodeLessThanField     = "less_than_field"
+	ErrorCodeNotComparable     = "not_comparable"
+	ErrorCodeRequiredIf        = "required_if"
+	ErrorCodeRequiredUnless    = "required_unless"
+	ErrorCodeMutuallyExclusive = "mutually_exclusive"
+	ErrorCodeNotEmpty          = "not_empty"
+	ErrorCodeRequired          = "required"
+	ErrorCodeMinLength         = "min_length"
+	ErrorCodeMaxLength         = "max_length"
+	ErrorCodeIp                = "ip"
+	ErrorCodeIpv4              = "ipv4"
+	ErrorCodeIpv6              = "ipv6"
+	ErrorCodeRegex             = "regex"
+	ErrorCodeUrl               = "url"
+	ErrorCodeUrlScheme         = "url_scheme"
+	ErrorCodeEmail             = "email"
+	ErrorCodeHostname          = "hostname"
+	ErrorCodeHostPort          = "host_port"
+	ErrorCodeCidr              = "cidr"
+	ErrorCodeMacAddress        = "mac_address"
+	ErrorCodeUuid              = "uuid"
+	ErrorCodeSemVer            = "semver"
+	ErrorCodePort              = "port"
+	ErrorCodeOneOf             = "one_of"
+	ErrorCodeNotOneOf          = "not_one_of"
+	ErrorCodePrefix            = "prefix"
+	ErrorCodeSuffix            = "suffix"
+	ErrorCodeContains          = "contains"
+	ErrorCodeCharacterClass    = "character_class"
+	ErrorCodeMissingCharacter  = "missing_character"
+	ErrorCodeInteger           = "integer"
+	ErrorCodeMin               = "min"
+	ErrorCodeMax               = "max"
+	ErrorCodeChoice            = "choice"
+	ErrorCodeExpression        = "expression"
+	ErrorCodeNot               = "not"
+	ErrorCodeAnyOf             = "any_of"
+	ErrorCodeAllOf             = "all_of"
+)
+
 type CustomError struct {
+	Code    string
 	Message string
 }
 
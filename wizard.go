@@ -0,0 +1,220 @@
+package go_forms
+
+import "strconv"
+
+// Defining the Wizard Page Type used to split a form into multiple pages
+
+type WizardPage struct {
+	Id                string
+	Title             string
+	DisplayConditions []DisplayCondition
+	Fields            []Field
+	// base is passed to the display conditions so that they can access the form like for fields
+	base *FieldBaseType
+}
+
+func (p *WizardPage) GetId() string {
+	return p.Id
+}
+
+func (p *WizardPage) GetTitle() string {
+	return p.Title
+}
+
+// ShouldDisplay returns false if the page should be skipped
+func (p *WizardPage) ShouldDisplay() bool {
+	for _, displayCondition := range p.DisplayConditions {
+		if !displayCondition.DisplayCondition(p.base) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *WizardPage) GetFieldsToDisplay() []Field {
+	var fieldsToDisplay []Field
+	for _, field := range p.Fields {
+		if field.ShouldDisplay() {
+			fieldsToDisplay = append(fieldsToDisplay, field)
+		}
+	}
+	return fieldsToDisplay
+}
+
+func (p *WizardPage) IsValid() bool {
+	for _, field := range p.Fields {
+		if !field.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *WizardPage) GetError() error {
+	return getFieldsError(p.Fields)
+}
+
+// Defining the wizard functions of the Form Type
+
+// IsWizard returns true if the form was created with NewWizard
+func (f *Form) IsWizard() bool {
+	return len(f.pages) > 0
+}
+
+func (f *Form) GetPages() []*WizardPage {
+	return f.pages
+}
+
+// GetCurrentPage returns the page the user is on or nil if the form is not a wizard
+func (f *Form) GetCurrentPage() *WizardPage {
+	if !f.IsWizard() {
+		return nil
+	}
+	return f.pages[f.getCurrentPageIndex()]
+}
+
+func (f *Form) getCurrentPageIndex() int {
+	f.stateMu.RLock()
+	defer f.stateMu.RUnlock()
+	return f.currentPage
+}
+
+func (f *Form) setCurrentPageIndex(index int) {
+	f.storeCurrentPageIndex(index)
+	f.triggerAutoSave()
+}
+
+// storeCurrentPageIndex switches the page without saving the draft, for changes that trigger the auto save themselves
+func (f *Form) storeCurrentPageIndex(index int) {
+	f.stateMu.Lock()
+	defer f.stateMu.Unlock()
+	f.currentPage = index
+}
+
+// SetCurrentPage switches to the page with the given id without validating the current page
+func (f *Form) SetCurrentPage(id string) bool {
+	index := f.pageIndex(id)
+	if index == -1 {
+		return false
+	}
+	f.setCurrentPageIndex(index)
+	return true
+}
+
+// pageIndex returns the index of the page with the given id or -1
+func (f *Form) pageIndex(id string) int {
+	for index, page := range f.pages {
+		if page.Id == id {
+			return index
+		}
+	}
+	return -1
+}
+
+// NextPage validates the current page and switches to the next page that should be displayed
+func (f *Form) NextPage() error {
+	if !f.IsWizard() {
+		return nil
+	}
+	if err := f.GetCurrentPage().GetError(); err != nil {
+		return err
+	}
+	next := f.findPage(f.getCurrentPageIndex()+1, 1)
+	if next == -1 {
+		return &CustomError{Message: "There is no next page (current page: " + f.GetCurrentPage().Id + ")"}
+	}
+	f.setCurrentPageIndex(next)
+	return nil
+}
+
+// PreviousPage switches to the previous page that should be displayed, the values of the current page are kept
+func (f *Form) PreviousPage() bool {
+	if !f.IsWizard() {
+		return false
+	}
+	previous := f.findPage(f.getCurrentPageIndex()-1, -1)
+	if previous == -1 {
+		return false
+	}
+	f.setCurrentPageIndex(previous)
+	return true
+}
+
+func (f *Form) IsFirstPage() bool {
+	return !f.IsWizard() || f.findPage(f.getCurrentPageIndex()-1, -1) == -1
+}
+
+func (f *Form) IsLastPage() bool {
+	return !f.IsWizard() || f.findPage(f.getCurrentPageIndex()+1, 1) == -1
+}
+
+// GetProgress returns the number of the current page and the number of pages that should be displayed (both counting from 1)
+func (f *Form) GetProgress() (int, int) {
+	current, total := 0, 0
+	currentPage := f.getCurrentPageIndex()
+	for index, page := range f.pages {
+		if index != currentPage && !page.ShouldDisplay() {
+			continue
+		}
+		total++
+		if index <= currentPage {
+			current = total
+		}
+	}
+	return current, total
+}
+
+// GetProgressText returns the progress as text like "Step 2 of 4"
+func (f *Form) GetProgressText() string {
+	current, total := f.GetProgress()
+	return "Step " + strconv.Itoa(current) + " of " + strconv.Itoa(total)
+}
+
+// findPage returns the index of the first page that should be displayed starting at start in the given direction or -1
+func (f *Form) findPage(start int, direction int) int {
+	for index := start; index >= 0 && index < len(f.pages); index += direction {
+		if f.pages[index].ShouldDisplay() {
+			return index
+		}
+	}
+	return -1
+}
+
+// getFieldsToValidate returns the top level fields of the form or the fields of the pages that should be displayed
+func (f *Form) getFieldsToValidate() []Field {
+	if !f.IsWizard() {
+		return f.Fields
+	}
+	var fields []Field
+	currentPage := f.getCurrentPageIndex()
+	for index, page := range f.pages {
+		if index == currentPage || page.ShouldDisplay() {
+			fields = append(fields, page.Fields...)
+		}
+	}
+	return fields
+}
+
+// Defining the wizard builder functions
+
+// NewWizardPage creates a new wizard page with the given fields
+func NewWizardPage(id string, title string, displayConditions []DisplayCondition, fields ...Field) *WizardPage {
+	return &WizardPage{Id: id, Title: title, DisplayConditions: displayConditions, Fields: fields, base: &FieldBaseType{Id: id}}
+}
+
+// NewWizard creates a new form that is displayed page by page
+func NewWizard(pages ...*WizardPage) *Form {
+	var fields []Field
+	for _, page := range pages {
+		fields = append(fields, page.Fields...)
+	}
+	form := NewForm(fields...)
+	form.pages = pages
+	for _, page := range pages {
+		page.base.form = form
+	}
+	if first := form.findPage(0, 1); first != -1 {
+		form.currentPage = first
+	}
+	return form
+}
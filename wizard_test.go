@@ -0,0 +1,134 @@
+package go_forms
+
+import "testing"
+
+// newTestWizard creates a wizard whose plugins page is only displayed for paper and whose trailing advanced page is only displayed in the advanced mode
+func newTestWizard(name string, software string, mode string) *Form {
+	nameField := NewTextField("name", nil, nil, "", "Name", name)
+	nameField.Required = true
+	return NewWizard(
+		NewWizardPage("general", "General", nil,
+			nameField,
+			NewTextField("software", nil, nil, "", "Software", software),
+		),
+		NewWizardPage("plugins", "Plugins", []DisplayCondition{&HasValueDisplayCondition{FieldId: "software", Value: "paper"}},
+			NewTextField("plugins", nil, nil, "", "Plugins", ""),
+		),
+		NewWizardPage("network", "Network", nil,
+			NewTextField("mode", nil, nil, "", "Mode", mode),
+		),
+		NewWizardPage("advanced", "Advanced", []DisplayCondition{&HasValueDisplayCondition{FieldId: "mode", Value: "advanced"}},
+			NewTextField("threads", nil, nil, "", "Threads", ""),
+		),
+	)
+}
+
+func TestWizardNextPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		software string
+		mode     string
+		start    string
+		// page is the page after NextPage, err is true if NextPage returns an error
+		page string
+		err  bool
+	}{
+		{name: "next page", value: "lobby", software: "paper", mode: "simple", start: "general", page: "plugins"},
+		{name: "skips hidden pages", value: "lobby", software: "vanilla", mode: "simple", start: "general", page: "network"},
+		{name: "invalid page", value: "", software: "paper", mode: "simple", start: "general", page: "general", err: true},
+		{name: "hidden trailing page", value: "lobby", software: "vanilla", mode: "simple", start: "network", page: "network", err: true},
+		{name: "displayed trailing page", value: "lobby", software: "vanilla", mode: "advanced", start: "network", page: "advanced"},
+		{name: "last page", value: "lobby", software: "vanilla", mode: "advanced", start: "advanced", page: "advanced", err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			form := newTestWizard(test.value, test.software, test.mode)
+			form.SetCurrentPage(test.start)
+			err := form.NextPage()
+			if (err != nil) != test.err {
+				t.Errorf("NextPage returned %v, expected an error: %v", err, test.err)
+			}
+			if page := form.GetCurrentPage().Id; page != test.page {
+				t.Errorf("current page is %s, expected %s", page, test.page)
+			}
+		})
+	}
+}
+
+func TestWizardPreviousPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		software string
+		start    string
+		// page is the page after PreviousPage, moved is its result
+		page  string
+		moved bool
+	}{
+		{name: "first page", software: "paper", start: "general", page: "general", moved: false},
+		{name: "previous page", software: "paper", start: "network", page: "plugins", moved: true},
+		{name: "skips hidden pages", software: "vanilla", start: "network", page: "general", moved: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			form := newTestWizard("lobby", test.software, "simple")
+			form.SetCurrentPage(test.start)
+			if moved := form.PreviousPage(); moved != test.moved {
+				t.Errorf("PreviousPage returned %v, expected %v", moved, test.moved)
+			}
+			if page := form.GetCurrentPage().Id; page != test.page {
+				t.Errorf("current page is %s, expected %s", page, test.page)
+			}
+		})
+	}
+}
+
+func TestWizardProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		software string
+		mode     string
+		page     string
+		current  int
+		total    int
+		text     string
+		first    bool
+		last     bool
+	}{
+		{name: "first page", software: "paper", mode: "simple", page: "general", current: 1, total: 3, text: "Step 1 of 3", first: true},
+		{name: "hidden pages are not counted", software: "vanilla", mode: "simple", page: "general", current: 1, total: 2, text: "Step 1 of 2", first: true},
+		{name: "last page before a hidden trailing page", software: "vanilla", mode: "simple", page: "network", current: 2, total: 2, text: "Step 2 of 2", last: true},
+		{name: "page before a displayed trailing page", software: "paper", mode: "advanced", page: "network", current: 3, total: 4, text: "Step 3 of 4"},
+		{name: "displayed trailing page", software: "paper", mode: "advanced", page: "advanced", current: 4, total: 4, text: "Step 4 of 4", last: true},
+		// The current page is counted even if it should not be displayed
+		{name: "hidden current page", software: "vanilla", mode: "simple", page: "plugins", current: 2, total: 3, text: "Step 2 of 3"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			form := newTestWizard("lobby", test.software, test.mode)
+			form.SetCurrentPage(test.page)
+			if current, total := form.GetProgress(); current != test.current || total != test.total {
+				t.Errorf("progress is %d of %d, expected %d of %d", current, total, test.current, test.total)
+			}
+			if text := form.GetProgressText(); text != test.text {
+				t.Errorf("progress text is %q, expected %q", text, test.text)
+			}
+			if form.IsFirstPage() != test.first || form.IsLastPage() != test.last {
+				t.Errorf("first page: %v, last page: %v, expected %v and %v", form.IsFirstPage(), form.IsLastPage(), test.first, test.last)
+			}
+		})
+	}
+}
+
+func TestWizardFirstPageIsDisplayed(t *testing.T) {
+	form := NewWizard(
+		NewWizardPage("plugins", "Plugins", []DisplayCondition{&CustomDisplayCondition{Condition: func(any) bool { return false }}}),
+		NewWizardPage("general", "General", nil),
+	)
+	if page := form.GetCurrentPage().Id; page != "general" {
+		t.Errorf("current page is %s, expected general", page)
+	}
+	if !form.IsFirstPage() {
+		t.Errorf("general is not the first page")
+	}
+}